@@ -0,0 +1,63 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// SetField sets the value of the named field of type [Required] of the structure obj points to
+// and marks the field as valid. It is meant for building fixtures in tests.
+// SetField returns an error wrapping [ErrBadType] if there is no such field or the value is ill-typed,
+// and one wrapping [ErrFrozen] if the field is frozen.
+func SetField(obj interface{}, name string, value interface{}) error {
+	v, err := structPointer(obj)
+	if err != nil {
+		return err
+	}
+	f, ok := v.Type().FieldByName(name)
+	if !ok || !f.IsExported() || !isRequiredField(f.Type) {
+		return fmt.Errorf("%w: no required field '%s' in '%s'", ErrBadType, name, v.Type())
+	}
+	fv, err := fieldByIndexAlloc(v, f.Index)
+	if err != nil {
+		return err
+	}
+	x, _ := requiredAt(fv, true)
+	if err := writable(x); err != nil {
+		return fmt.Errorf("field '%s' in '%s' %w", name, v.Type(), err)
+	}
+	t := x.RequiredType()
+	var nv reflect.Value
+	switch {
+	case value == nil && canBeNil(t):
+		nv = reflect.Zero(t)
+	case value != nil && reflect.TypeOf(value).AssignableTo(t):
+		nv = reflect.ValueOf(value)
+	default:
+		return fmt.Errorf("%w: cannot set field '%s' of type %s in '%s' to %T", ErrBadType, name, t, v.Type(), value)
+	}
+	x.SettableValue().Set(nv)
+	x.SetValid(true)
+	return nil
+}
+
+func canBeNil(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func:
+		return true
+	}
+	return false
+}
+
+// Set returns a setter which sets the underlying value of the field and marks it as valid,
+// so that valid structures can be built functionally:
+//
+//	setName := validate.Set(&p.Name)
+//	setName("Saoirse")
+//
+// A frozen field is left intact.
+func Set[T any](field *Required[T]) func(T) {
+	return func(v T) {
+		_ = field.Set(v)
+	}
+}
@@ -0,0 +1,78 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRequired(t *testing.T) {
+	req := require.New(t)
+
+	p := Person{Name: NewRequired("Saoirse"), Age: NewRequired(25)}
+	req.NoError(Struct(&p))
+	req.Equal(SourceManual, p.Name.Source())
+}
+
+func TestSetField(t *testing.T) {
+	req := require.New(t)
+
+	var p Person
+	req.NoError(SetField(&p, "Name", "Saoirse"))
+	req.Error(Struct(&p))
+	req.NoError(SetField(&p, "Age", 25))
+	req.NoError(Struct(&p))
+	req.Equal("Saoirse", p.Name.Value())
+	req.Equal(25, p.Age.Value())
+	req.Equal(SourceManual, p.Age.Source())
+
+	err := SetField(&p, "Age", "twenty-five")
+	req.ErrorIs(err, ErrBadType)
+	req.Equal("bad type: cannot set field 'Age' of type int in 'validate.Person' to string", err.Error())
+	req.Equal(25, p.Age.Value())
+
+	req.ErrorIs(SetField(&p, "Age", nil), ErrBadType)
+	req.ErrorIs(SetField(&p, "Height", 180), ErrBadType)
+	req.ErrorIs(SetField(p, "Age", 25), ErrBadType)
+
+	var a Attachment
+	req.NoError(SetField(&a, "Data", nil))
+	req.True(a.Data.HasValue())
+
+	var d Derived
+	req.NoError(SetField(&d, "ID", 7))
+	req.NoError(SetField(&d, "Name", "Saoirse"))
+	req.NoError(Struct(&d))
+}
+
+func TestSet(t *testing.T) {
+	req := require.New(t)
+
+	var p Person
+	setters := []func(*Person){
+		func(p *Person) { Set(&p.Name)("Saoirse") },
+		func(p *Person) { Set(&p.Age)(25) },
+	}
+	for _, set := range setters {
+		req.Error(Struct(&p))
+		set(&p)
+	}
+	req.NoError(Struct(&p))
+	req.Equal("Saoirse", p.Name.Get())
+	req.Equal(25, p.Age.Get())
+	req.Equal(SourceManual, p.Age.Source())
+
+	setAge := Set(&p.Age)
+	Freeze(&p)
+	setAge(30)
+	req.Equal(25, p.Age.Get())
+}
+
+func TestSetFieldPointer(t *testing.T) {
+	req := require.New(t)
+
+	var p Patch
+	req.NoError(SetField(&p, "Count", 1))
+	req.Equal(1, p.Count.Value())
+	req.ErrorIs(SetField(&p, "Count", "one"), ErrBadType)
+}
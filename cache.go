@@ -0,0 +1,57 @@
+package validate
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"reflect"
+	"sync"
+)
+
+// cacheKey identifies a payload decoded into a type.
+type cacheKey struct {
+	t   reflect.Type
+	sum [sha256.Size]byte
+}
+
+// lru is a bounded set of keys evicting the least recently used ones.
+type lru struct {
+	mu    sync.Mutex
+	size  int
+	order *list.List
+	items map[cacheKey]*list.Element
+}
+
+func newLRU(size int) *lru {
+	return &lru{size: size, order: list.New(), items: make(map[cacheKey]*list.Element, size)}
+}
+
+// contains tells whether the key is present, marking it as recently used.
+func (c *lru) contains(k cacheKey) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[k]
+	if ok {
+		c.order.MoveToFront(e)
+	}
+	return ok
+}
+
+func (c *lru) add(k cacheKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[k]; ok {
+		c.order.MoveToFront(e)
+		return
+	}
+	c.items[k] = c.order.PushFront(k)
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(cacheKey))
+	}
+}
+
+// payloadKey hashes the payload and pairs it with the type it is decoded into.
+func payloadKey(t reflect.Type, b []byte) cacheKey {
+	return cacheKey{t: t, sum: sha256.Sum256(b)}
+}
@@ -0,0 +1,116 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCache(t *testing.T) {
+	req := require.New(t)
+
+	vd := New(WithCache(2))
+	parse := func(s string) error {
+		var p Person
+		return vd.Parse(strings.NewReader(s), &p)
+	}
+	cached := func(s string) bool {
+		_, ok := vd.cache.items[payloadKey(reflect.TypeFor[Person](), []byte(s))]
+		return ok
+	}
+
+	req.NoError(parse(`{"name":"Saoirse","age":25}`))
+	req.True(cached(`{"name":"Saoirse","age":25}`))
+	req.NoError(parse(`{"name":"Saoirse","age":25}`))
+
+	// invalid payloads are never cached
+	req.Error(parse(`{"name":"Saoirse"}`))
+	req.False(cached(`{"name":"Saoirse"}`))
+	req.Error(parse(`{"name":"Saoirse"}`))
+
+	// the least recently used entry is evicted
+	req.NoError(parse(`{"name":"Aoife","age":30}`))
+	req.NoError(parse(`{"name":"Niamh","age":35}`))
+	req.False(cached(`{"name":"Saoirse","age":25}`))
+	req.True(cached(`{"name":"Aoife","age":30}`))
+	req.True(cached(`{"name":"Niamh","age":35}`))
+
+	// the type is part of the key
+	var c Contact
+	req.Error(vd.Parse(strings.NewReader(`{"name":"Niamh","age":35}`), &c))
+}
+
+func TestWithCacheSameTypeName(t *testing.T) {
+	req := require.New(t)
+
+	vd := New(WithCache(8))
+	b := []byte(`{"name":"Saoirse","age":25}`)
+	var p Person
+	req.NoError(vd.ParseBytes(b, &p))
+
+	// a different type of the same name
+	type Person struct {
+		Name Required[string] `json:"name"`
+		Age  Required[int]    `json:"age"`
+		Zip  Required[string] `json:"zip"`
+	}
+	var other Person
+	req.EqualError(vd.ParseBytes(b, &other), "field 'Zip' in 'validate.Person' is required")
+}
+
+func TestWithCacheCallbacks(t *testing.T) {
+	req := require.New(t)
+
+	var reported []string
+	vd := New(WithCache(8), WithDeprecationWarning(func(field string) { reported = append(reported, field) }))
+	b := []byte(`{"host":"localhost","port":8080,"address":"localhost:8080"}`)
+	for i := 0; i < 2; i++ {
+		var l Listener
+		req.NoError(vd.ParseBytes(b, &l))
+	}
+	req.Equal([]string{"Address", "Address"}, reported)
+	req.Empty(vd.cache.items)
+}
+
+func TestWithCacheConcurrent(t *testing.T) {
+	req := require.New(t)
+
+	vd := New(WithCache(8))
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var p Person
+			req.NoError(vd.Parse(strings.NewReader(`{"name":"Saoirse","age":25}`), &p))
+		}()
+	}
+	wg.Wait()
+	req.Len(vd.cache.items, 1)
+}
+
+const largePayload = `{"id":"o1","address":{"street":"Main","zip":"12345"},"billing":{"street":"Main","zip":"12345"},
+"items":[{"sku":"a"},{"sku":"b"},{"sku":"c"},{"sku":"d"},{"sku":"e"},{"sku":"f"},{"sku":"g"},{"sku":"h"}]}`
+
+func BenchmarkParseWithoutCache(b *testing.B) {
+	vd := New()
+	for i := 0; i < b.N; i++ {
+		var o Order
+		if err := vd.Parse(strings.NewReader(largePayload), &o); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseWithCache(b *testing.B) {
+	vd := New(WithCache(16))
+	for i := 0; i < b.N; i++ {
+		var o Order
+		if err := vd.Parse(strings.NewReader(largePayload), &o); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
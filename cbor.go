@@ -0,0 +1,67 @@
+package validate
+
+import (
+	"io"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+var (
+	_ cbor.Unmarshaler = (*Required[int])(nil)
+	_ cbor.Marshaler   = (*Required[int])(nil)
+)
+
+// cborNull and cborUndefined are the encodings of the CBOR simple values null and undefined.
+const (
+	cborNull      = 0xf6
+	cborUndefined = 0xf7
+)
+
+func init() {
+	RegisterContentDecoder("application/cbor", DecoderFunc(func(r io.Reader, obj interface{}) error {
+		return cbor.NewDecoder(r).Decode(obj)
+	}))
+}
+
+// UnmarshalCBOR unmarshals the underlying value from CBOR and marks the instance as valid and touched.
+// CBOR null and undefined are treated like a JSON `null`: the instance becomes valid and [Required.WasNull] reports them.
+// A frozen instance is left intact and [ErrFrozen] is returned.
+func (r *Required[T]) UnmarshalCBOR(b []byte) error {
+	if r.frozen {
+		return ErrFrozen
+	}
+	null := len(b) == 1 && (b[0] == cborNull || b[0] == cborUndefined)
+	if !null {
+		if err := cbor.Unmarshal(b, &r.value); err != nil {
+			return err
+		}
+	}
+	r.valid = true
+	r.touched = true
+	r.null = null
+	r.source = SourceCBOR
+	return nil
+}
+
+// MarshalCBOR marshals the underlying value to CBOR, or to CBOR null if there is none.
+func (r *Required[T]) MarshalCBOR() ([]byte, error) {
+	if !r.valid {
+		return []byte{cborNull}, nil
+	}
+	return cbor.Marshal(r.value)
+}
+
+// ParseCBOR decodes a CBOR data item into the provided struct instance and validates it like [Parse].
+// Fields of type [Required] are matched with the keys of CBOR maps like by [cbor.Unmarshal],
+// i.e. by their `cbor` or `json` tags.
+func ParseCBOR(r io.Reader, obj interface{}) error {
+	return defaultValidator.ParseCBOR(r, obj)
+}
+
+// ParseCBOR is like [ParseCBOR] but validates the object with the validator.
+func (vd *Validator) ParseCBOR(r io.Reader, obj interface{}) error {
+	if err := cbor.NewDecoder(r).Decode(obj); err != nil {
+		return err
+	}
+	return vd.Struct(obj)
+}
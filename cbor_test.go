@@ -0,0 +1,69 @@
+package validate
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCBOR(t *testing.T) {
+	req := require.New(t)
+
+	b, err := cbor.Marshal(map[string]interface{}{"name": "Saoirse", "age": 25})
+	req.NoError(err)
+	var p Person
+	req.NoError(ParseCBOR(bytes.NewReader(b), &p))
+	req.Equal("Saoirse", p.Name.Get())
+	req.Equal(25, p.Age.Get())
+	req.Equal(SourceCBOR, p.Age.Source())
+	req.True(p.Age.Touched())
+
+	b, err = cbor.Marshal(map[string]interface{}{"name": "Saoirse"})
+	req.NoError(err)
+	p = Person{}
+	err = ParseCBOR(bytes.NewReader(b), &p)
+	req.Equal("field 'Age' in 'validate.Person' is required", err.Error())
+
+	b, err = cbor.Marshal(map[string]interface{}{"name": "Saoirse", "age": "old"})
+	req.NoError(err)
+	p = Person{}
+	req.Error(ParseCBOR(bytes.NewReader(b), &p))
+	req.False(p.Age.HasValue())
+
+	for _, null := range []byte{cborNull, cborUndefined} {
+		b := append([]byte{0xa2, 0x64, 'n', 'a', 'm', 'e', null, 0x63, 'a', 'g', 'e'}, 0x18, 25)
+		p = Person{}
+		req.NoError(ParseCBOR(bytes.NewReader(b), &p))
+		req.True(p.Name.HasValue())
+		req.True(p.Name.WasNull())
+		req.Equal(25, p.Age.Get())
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/people", bytes.NewReader([]byte{0xa1, 0x64, 'n', 'a', 'm', 'e', 0x61, 'S'}))
+	r.Header.Set("Content-Type", "application/cbor")
+	p = Person{}
+	err = Bind(r, &p)
+	req.Equal("field 'Age' in 'validate.Person' is required", err.Error())
+	req.Equal("S", p.Name.Get())
+}
+
+func TestMarshalCBOR(t *testing.T) {
+	req := require.New(t)
+
+	p := Person{Name: NewRequired("Saoirse")}
+	b, err := cbor.Marshal(&p)
+	req.NoError(err)
+
+	var m map[string]interface{}
+	req.NoError(cbor.Unmarshal(b, &m))
+	req.Equal(map[string]interface{}{"name": "Saoirse", "age": nil}, m)
+
+	var q Person
+	req.NoError(cbor.Unmarshal(b, &q))
+	req.Equal("Saoirse", q.Name.Get())
+	req.True(q.Age.WasNull())
+}
@@ -0,0 +1,56 @@
+package validate
+
+import "reflect"
+
+// EqualValue tells whether the instances hold equal values. Numeric values are compared by their values
+// regardless of their types, so `int(5)` equals `int64(5)` and `float64(5)`; other values are compared
+// with [reflect.DeepEqual]. Two instances without values are equal; an instance without a value
+// is not equal to one with a value.
+func EqualValue(a, b RequiredIface) bool {
+	if !a.HasValue() || !b.HasValue() {
+		return a.HasValue() == b.HasValue()
+	}
+	va, vb := a.SettableValue(), b.SettableValue()
+	if ka, kb := numericKind(va.Kind()), numericKind(vb.Kind()); ka != 0 && kb != 0 {
+		return equalNumbers(va, ka, vb, kb)
+	}
+	return reflect.DeepEqual(a.Value(), b.Value())
+}
+
+// numericKind returns the representative of the numeric kind k, that is,
+// [reflect.Int], [reflect.Uint] or [reflect.Float64], or zero if k is not numeric.
+func numericKind(k reflect.Kind) reflect.Kind {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflect.Uint
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	}
+	return 0
+}
+
+func equalNumbers(a reflect.Value, ka reflect.Kind, b reflect.Value, kb reflect.Kind) bool {
+	switch {
+	case ka == reflect.Int && kb == reflect.Int:
+		return a.Int() == b.Int()
+	case ka == reflect.Uint && kb == reflect.Uint:
+		return a.Uint() == b.Uint()
+	case ka == reflect.Int && kb == reflect.Uint:
+		return a.Int() >= 0 && uint64(a.Int()) == b.Uint()
+	case ka == reflect.Uint && kb == reflect.Int:
+		return b.Int() >= 0 && a.Uint() == uint64(b.Int())
+	}
+	return toFloat(a, ka) == toFloat(b, kb)
+}
+
+func toFloat(v reflect.Value, k reflect.Kind) float64 {
+	switch k {
+	case reflect.Int:
+		return float64(v.Int())
+	case reflect.Uint:
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
@@ -0,0 +1,38 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEqualValue(t *testing.T) {
+	req := require.New(t)
+
+	var (
+		i    = NewRequired(5)
+		i64  = NewRequired[int64](5)
+		u8   = NewRequired[uint8](5)
+		f    = NewRequired(5.0)
+		f32  = NewRequired[float32](5.5)
+		neg  = NewRequired(-1)
+		huge = NewRequired[uint64](1<<64 - 1)
+	)
+	req.True(EqualValue(&i, &i64))
+	req.True(EqualValue(&i64, &u8))
+	req.True(EqualValue(&u8, &f))
+	req.True(EqualValue(&f, &i))
+	req.False(EqualValue(&f, &f32))
+	req.False(EqualValue(&neg, &huge))
+	req.False(EqualValue(&huge, &neg))
+
+	s, n := NewRequired("5"), NewRequired(5)
+	req.False(EqualValue(&s, &n))
+	xs, ys := NewRequired([]int{1, 2}), NewRequired([]int{1, 2})
+	req.True(EqualValue(&xs, &ys))
+
+	var unset, unset64 Required[int]
+	req.True(EqualValue(&unset, &unset64))
+	req.False(EqualValue(&unset, &i))
+	req.False(EqualValue(&i, &unset))
+}
@@ -0,0 +1,56 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// mandatory tells whether the field of type [Required] of the structure v must have a value.
+// A field tagged with `required_if:"Field=value"` is only mandatory if its sibling has the given value,
+// and a field tagged with `required_unless:"Field=value"` is mandatory unless its sibling has the given value.
+func mandatory(v reflect.Value, f reflect.StructField) (bool, error) {
+	if cond, ok := f.Tag.Lookup("required_if"); ok {
+		return condition(v, "required_if", cond)
+	}
+	if cond, ok := f.Tag.Lookup("required_unless"); ok {
+		holds, err := condition(v, "required_unless", cond)
+		return !holds, err
+	}
+	return true, nil
+}
+
+// condition evaluates the condition `Field=value` against the structure v.
+// The condition holds if the field has a value whose textual representation equals the given one.
+func condition(v reflect.Value, key, cond string) (bool, error) {
+	name, want, ok := strings.Cut(cond, "=")
+	if !ok {
+		return false, malformedTag(key, cond)
+	}
+	sf, ok := v.Type().FieldByName(name)
+	if !ok || !sf.IsExported() {
+		return false, malformedTag(key, cond)
+	}
+	fv, err := v.FieldByIndexErr(sf.Index)
+	if err != nil {
+		// the field is promoted through a nil embedded pointer
+		return false, nil
+	}
+	if fv.Kind() == reflect.Pointer && fv.Type().Implements(RequiredIfaceType) {
+		if fv.IsNil() {
+			return false, nil
+		}
+		fv = fv.Elem()
+	}
+	if x, ok := fv.Addr().Interface().(RequiredIface); ok {
+		if !x.HasValue() {
+			return false, nil
+		}
+		fv = x.SettableValue()
+	}
+	return display(fv) == want, nil
+}
+
+func malformedTag(key, value string) error {
+	return fmt.Errorf("has a malformed tag %s:%q: %w", key, value, ErrBadType)
+}
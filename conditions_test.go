@@ -0,0 +1,102 @@
+package validate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Subscription struct {
+	Type    Required[string] `json:"type"`
+	Card    Required[string] `json:"card" required_if:"Type=premium"`
+	Seats   int              `json:"seats"`
+	Manager Required[string] `json:"manager" required_if:"Seats=10"`
+}
+
+func TestRequiredIf(t *testing.T) {
+	req := require.New(t)
+
+	var s Subscription
+	err := json.Unmarshal([]byte(`{"type":"free"}`), &s)
+	req.NoError(err)
+	req.NoError(Struct(&s))
+
+	s = Subscription{}
+	err = json.Unmarshal([]byte(`{"type":"premium"}`), &s)
+	req.NoError(err)
+	err = Struct(&s)
+	req.Equal("field 'Card' in 'validate.Subscription' is required", err.Error())
+
+	s = Subscription{}
+	err = json.Unmarshal([]byte(`{"type":"premium","card":"4111"}`), &s)
+	req.NoError(err)
+	req.NoError(Struct(&s))
+
+	s = Subscription{}
+	err = json.Unmarshal([]byte(`{"type":"free","seats":10}`), &s)
+	req.NoError(err)
+	err = Struct(&s)
+	req.Equal("field 'Manager' in 'validate.Subscription' is required", err.Error())
+
+	// the condition does not hold if the field it refers to is missing
+	s = Subscription{}
+	err = json.Unmarshal([]byte(`{}`), &s)
+	req.NoError(err)
+	err = Struct(&s)
+	req.Equal("field 'Type' in 'validate.Subscription' is required", err.Error())
+}
+
+type badCondition struct {
+	A Required[string] `required_if:"B"`
+	C Required[string] `required_if:"Missing=x"`
+}
+
+func TestRequiredIfMalformed(t *testing.T) {
+	req := require.New(t)
+
+	var b badCondition
+	err := Struct(&b)
+	req.ErrorIs(err, ErrBadType)
+	req.Equal("field 'A' in 'validate.badCondition' has a malformed tag required_if:\"B\": bad type\n"+
+		"field 'C' in 'validate.badCondition' has a malformed tag required_if:\"Missing=x\": bad type", err.Error())
+}
+
+type Grant struct {
+	Role   Required[string] `json:"role"`
+	Reason Required[string] `json:"reason" required_unless:"Role=admin"`
+}
+
+func TestRequiredUnless(t *testing.T) {
+	req := require.New(t)
+
+	// the condition is met, so the field is optional
+	var g Grant
+	err := json.Unmarshal([]byte(`{"role":"admin"}`), &g)
+	req.NoError(err)
+	req.NoError(Struct(&g))
+
+	g = Grant{}
+	err = json.Unmarshal([]byte(`{"role":"admin","reason":"audit"}`), &g)
+	req.NoError(err)
+	req.NoError(Struct(&g))
+
+	// the condition is not met, so the field is required
+	g = Grant{}
+	err = json.Unmarshal([]byte(`{"role":"viewer"}`), &g)
+	req.NoError(err)
+	err = Struct(&g)
+	req.Equal("field 'Reason' in 'validate.Grant' is required", err.Error())
+
+	g = Grant{}
+	err = json.Unmarshal([]byte(`{"role":"viewer","reason":"audit"}`), &g)
+	req.NoError(err)
+	req.NoError(Struct(&g))
+
+	// a missing role does not meet the condition
+	g = Grant{}
+	err = json.Unmarshal([]byte(`{}`), &g)
+	req.NoError(err)
+	err = Struct(&g)
+	req.Equal("field 'Role' in 'validate.Grant' is required\nfield 'Reason' in 'validate.Grant' is required", err.Error())
+}
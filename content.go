@@ -0,0 +1,80 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+	"sync"
+)
+
+// Decoder decodes the contents of a reader into the object obj points to.
+type Decoder interface {
+	Decode(r io.Reader, obj interface{}) error
+}
+
+// DecoderFunc is an adapter allowing the use of ordinary functions as [Decoder] implementations.
+type DecoderFunc func(r io.Reader, obj interface{}) error
+
+// Decode calls f(r, obj).
+func (f DecoderFunc) Decode(r io.Reader, obj interface{}) error { return f(r, obj) }
+
+// jsonDecoder decodes JSON. [Validator.ParseWith] delegates to [Validator.Parse] for it.
+type jsonDecoder struct{}
+
+func (jsonDecoder) Decode(r io.Reader, obj interface{}) error { return json.NewDecoder(r).Decode(obj) }
+
+// contentDecoders maps media types to their decoders.
+var contentDecoders sync.Map
+
+func init() {
+	RegisterContentDecoder("application/json", jsonDecoder{})
+}
+
+// RegisterContentDecoder registers a decoder for the media type, e.g. `application/yaml`,
+// to be used by [ParseWith] and [Bind]. JSON is registered by default and, unless registered otherwise,
+// serves the media types with the `+json` suffix too. A later registration for the same media type
+// replaces the earlier one.
+//
+// RegisterContentDecoder is safe for concurrent use.
+func RegisterContentDecoder(mediaType string, d Decoder) {
+	contentDecoders.Store(strings.ToLower(mediaType), d)
+}
+
+func lookupContentDecoder(mediaType string) (Decoder, bool) {
+	if d, ok := contentDecoders.Load(mediaType); ok {
+		return d.(Decoder), true
+	}
+	if strings.HasSuffix(mediaType, "+json") {
+		return jsonDecoder{}, true
+	}
+	return nil, false
+}
+
+// ParseWith decodes the contents of the reader into the provided struct instance with the decoder
+// registered for the content type by [RegisterContentDecoder] and validates it.
+// The content type may have parameters, e.g. `application/json; charset=utf-8`.
+// JSON is parsed like by [Parse]. An unregistered content type yields an error wrapping [ErrUnsupportedMediaType].
+func ParseWith(contentType string, r io.Reader, obj interface{}) error {
+	return defaultValidator.ParseWith(contentType, r, obj)
+}
+
+// ParseWith is like [ParseWith] but validates the object with the validator.
+func (vd *Validator) ParseWith(contentType string, r io.Reader, obj interface{}) error {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUnsupportedMediaType, err)
+	}
+	d, ok := lookupContentDecoder(mt)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnsupportedMediaType, mt)
+	}
+	if _, ok := d.(jsonDecoder); ok {
+		return vd.Parse(r, obj)
+	}
+	if err := d.Decode(r, obj); err != nil {
+		return err
+	}
+	return vd.Struct(obj)
+}
@@ -0,0 +1,64 @@
+package validate
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// decodePersonLines decodes `key: value` lines into a [Person].
+func decodePersonLines(r io.Reader, obj interface{}) error {
+	p := obj.(*Person)
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		key, value, _ := strings.Cut(sc.Text(), ": ")
+		switch key {
+		case "name":
+			p.Name.Set(value)
+		case "age":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return err
+			}
+			p.Age.Set(n)
+		}
+	}
+	return sc.Err()
+}
+
+func TestParseWith(t *testing.T) {
+	req := require.New(t)
+
+	RegisterContentDecoder("Text/X-Person", DecoderFunc(decodePersonLines))
+
+	var p Person
+	req.NoError(ParseWith("text/x-person; charset=utf-8", strings.NewReader("name: Saoirse\nage: 25\n"), &p))
+	req.Equal("Saoirse", p.Name.Value())
+	req.Equal(25, p.Age.Value())
+
+	p = Person{}
+	err := ParseWith("text/x-person", strings.NewReader("name: Saoirse\n"), &p)
+	req.Equal("field 'Age' in 'validate.Person' is required", err.Error())
+
+	p = Person{}
+	err = ParseWith("application/json", strings.NewReader(`{"name":"Saoirse"}`), &p)
+	req.Equal("field 'Age' in 'validate.Person' is required", err.Error())
+
+	p = Person{}
+	req.NoError(ParseWith("application/vnd.person+json", strings.NewReader(`{"name":"Saoirse","age":25}`), &p))
+
+	err = ParseWith("text/x-unknown", strings.NewReader(""), &p)
+	req.ErrorIs(err, ErrUnsupportedMediaType)
+
+	r := httptest.NewRequest(http.MethodPost, "/people", strings.NewReader("name: Saoirse\nage: 25\n"))
+	r.Header.Set("Content-Type", "text/x-person")
+	p = Person{}
+	req.NoError(Bind(r, &p))
+	req.Equal("Saoirse", p.Name.Value())
+}
@@ -0,0 +1,54 @@
+package validate
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/url"
+)
+
+// ParseCSVStream parses CSV with a header row, decoding each following row into a fresh object obtained from new
+// and validating it. The columns are named by the header and assigned like the values of [ParseValues],
+// that is, to the fields with the matching `form` tags or JSON names; empty cells count as absent values
+// and unknown columns are ignored. The callback out is invoked for every row with the object, the number
+// of the row counting the header as the first one, and the error, if any, that has occurred while decoding
+// or validating it. Parsing continues past malformed and invalid rows. If reading fails, out is invoked
+// with a nil object and the read error and parsing stops.
+func ParseCSVStream(r io.Reader, new func() interface{}, out func(obj interface{}, rowNum int, err error)) {
+	defaultValidator.ParseCSVStream(r, new, out)
+}
+
+// ParseCSVStream is like [ParseCSVStream] but validates the objects with the validator.
+func (vd *Validator) ParseCSVStream(r io.Reader, new func() interface{}, out func(obj interface{}, rowNum int, err error)) {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	header, err := cr.Read()
+	if err != nil {
+		if err != io.EOF {
+			out(nil, 1, err)
+		}
+		return
+	}
+	header = append([]string(nil), header...)
+	for row := 2; ; row++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			out(nil, row, err)
+			if perr := (*csv.ParseError)(nil); errors.As(err, &perr) {
+				continue
+			}
+			return
+		}
+		values := make(url.Values, len(record))
+		for i, cell := range record {
+			if cell != "" && i < len(header) {
+				values[header[i]] = append(values[header[i]], cell)
+			}
+		}
+		obj := new()
+		out(obj, row, vd.ParseValues(values, obj))
+	}
+}
@@ -0,0 +1,69 @@
+package validate
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSVStream(t *testing.T) {
+	req := require.New(t)
+
+	const data = `name,age,note
+Saoirse,25,first
+Niamh,,missing age
+Aoife,old,bad age
+Or"la,31,bare quote
+Ciara,40,last
+`
+	type result struct {
+		name string
+		row  int
+		err  string
+	}
+	var results []result
+	ParseCSVStream(strings.NewReader(data), func() interface{} { return new(Person) }, func(obj interface{}, row int, err error) {
+		r := result{row: row}
+		if obj != nil {
+			r.name = obj.(*Person).Name.Get()
+		}
+		if err != nil {
+			r.err = err.Error()
+		}
+		results = append(results, r)
+	})
+	req.Len(results, 5)
+	req.Equal(result{"Saoirse", 2, ""}, results[0])
+	req.Equal(result{"Niamh", 3, "field 'Age' in 'validate.Person' is required"}, results[1])
+	req.Equal(result{"Aoife", 4, `field 'Age' in 'validate.Person' is invalid: strconv.ParseInt: parsing "old": invalid syntax`}, results[2])
+	req.Equal(5, results[3].row)
+	req.Contains(results[3].err, csv.ErrBareQuote.Error())
+	req.Equal(result{"Ciara", 6, ""}, results[4])
+}
+
+func TestParseCSVStreamFieldCount(t *testing.T) {
+	req := require.New(t)
+
+	var (
+		rows []int
+		errs []error
+	)
+	ParseCSVStream(strings.NewReader("name,age\nSaoirse,25,extra\nNiamh,30\n"), func() interface{} { return new(Person) }, func(obj interface{}, row int, err error) {
+		rows = append(rows, row)
+		errs = append(errs, err)
+	})
+	req.Equal([]int{2, 3}, rows)
+	req.ErrorIs(errs[0], csv.ErrFieldCount)
+	req.NoError(errs[1])
+
+	var readErr error
+	ParseCSVStream(iotest.ErrReader(iotest.ErrTimeout), func() interface{} { return new(Person) }, func(obj interface{}, row int, err error) {
+		req.Nil(obj)
+		req.Equal(1, row)
+		readErr = err
+	})
+	req.ErrorIs(readErr, iotest.ErrTimeout)
+}
@@ -0,0 +1,110 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// decoders maps types to the functions decoding JSON into them.
+var decoders sync.Map
+
+// ErrAbsent is returned by a decoder registered with [RegisterDecoder] to have the JSON value
+// it has been given treated as if it were missing.
+var ErrAbsent = errors.New("absent")
+
+// RegisterDecoder registers a function decoding JSON into values of type T.
+// [Required.UnmarshalJSON] consults the registered decoders before falling back to [json.Unmarshal].
+// A decoder returning an error wrapping [ErrAbsent] leaves the instance without a value.
+// A later registration for the same type replaces the earlier one.
+//
+// RegisterDecoder is safe for concurrent use.
+func RegisterDecoder[T any](decode func([]byte) (T, error)) {
+	decoders.Store(reflect.TypeFor[T](), decode)
+}
+
+func lookupDecoder[T any]() (func([]byte) (T, error), bool) {
+	d, ok := decoders.Load(reflect.TypeFor[T]())
+	if !ok {
+		return nil, false
+	}
+	return d.(func([]byte) (T, error)), true
+}
+
+// DecodeEmptyAsAbsent decodes JSON into T like [json.Unmarshal] but treats an empty string as a missing value.
+// It is meant to be registered with [RegisterDecoder] for types such as decimals which accept
+// both numbers and strings but reject empty strings:
+//
+//	validate.RegisterDecoder(validate.DecodeEmptyAsAbsent[decimal.Decimal])
+func DecodeEmptyAsAbsent[T any](b []byte) (T, error) {
+	var v T
+	if bytes.Equal(bytes.TrimSpace(b), []byte(`""`)) {
+		return v, ErrAbsent
+	}
+	err := json.Unmarshal(b, &v)
+	return v, err
+}
+
+type integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// DecodeIntegral decodes a JSON number into an integer type, tolerating integral floats such as `25.0`
+// and exponents such as `1e3` but rejecting fractional ones such as `25.5`. The numbers are converted exactly,
+// without going through floating point, so large integers keep their precision and those overflowing the type
+// are rejected. It is opt-in, being meant to be registered with [RegisterDecoder]:
+//
+//	validate.RegisterDecoder(validate.DecodeIntegral[int])
+func DecodeIntegral[T integer](b []byte) (T, error) {
+	if b := bytes.TrimSpace(b); len(b) > 0 && b[0] == '"' {
+		return 0, &json.UnmarshalTypeError{Value: "string", Type: reflect.TypeFor[T]()}
+	}
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return 0, err
+	}
+	if i, err := n.Int64(); err == nil {
+		if t := T(i); int64(t) == i && (t < 0) == (i < 0) {
+			return t, nil
+		}
+		return 0, fmt.Errorf("json: number %s overflows %s", n, reflect.TypeFor[T]())
+	}
+	r, ok := parseExactNumber(n.String())
+	if !ok {
+		return 0, fmt.Errorf("json: number %s overflows %s", n, reflect.TypeFor[T]())
+	}
+	if !r.IsInt() {
+		return 0, fmt.Errorf("json: number %s is not an integer", n)
+	}
+	if i := r.Num(); i.IsInt64() {
+		if t := T(i.Int64()); int64(t) == i.Int64() && (t < 0) == (i.Sign() < 0) {
+			return t, nil
+		}
+	} else if i.IsUint64() {
+		if t := T(i.Uint64()); t > 0 && uint64(t) == i.Uint64() {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("json: number %s overflows %s", n, reflect.TypeFor[T]())
+}
+
+// maxExponent bounds the exponents of the numbers converted by [parseExactNumber].
+const maxExponent = 1000
+
+// parseExactNumber converts a JSON number to a rational number exactly.
+// It returns false for numbers whose exponents are too large to be converted cheaply.
+func parseExactNumber(s string) (*big.Rat, bool) {
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exp, err := strconv.Atoi(s[i+1:])
+		if err != nil || exp > maxExponent || exp < -maxExponent {
+			return nil, false
+		}
+	}
+	return new(big.Rat).SetString(s)
+}
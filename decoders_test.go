@@ -0,0 +1,198 @@
+package validate
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+type Event struct {
+	Name Required[string]    `json:"name"`
+	At   Required[time.Time] `json:"at"`
+}
+
+func decodeLenientTime(b []byte) (time.Time, error) {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return time.Time{}, err
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly, "02.01.2006"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	req := require.New(t)
+
+	var e Event
+	err := json.Unmarshal([]byte(`{"name":"launch","at":"2024-09-04"}`), &e)
+	req.Error(err)
+
+	RegisterDecoder(decodeLenientTime)
+	defer decoders.Delete(reflect.TypeFor[time.Time]())
+
+	for _, at := range []string{"2024-09-04T00:00:00Z", "2024-09-04", "04.09.2024"} {
+		e = Event{}
+		err = json.Unmarshal([]byte(`{"name":"launch","at":"`+at+`"}`), &e)
+		req.NoError(err, at)
+		req.NoError(Struct(&e))
+		req.Equal(time.Date(2024, 9, 4, 0, 0, 0, 0, time.UTC), e.At.Value())
+	}
+
+	e = Event{}
+	err = json.Unmarshal([]byte(`{"name":"launch","at":"tomorrow"}`), &e)
+	req.Error(err)
+	req.False(e.At.HasValue())
+
+	// the names are decoded as usual
+	req.Equal("launch", e.Name.Value())
+}
+
+func TestRegisterDecoderConcurrent(t *testing.T) {
+	defer decoders.Delete(reflect.TypeFor[time.Time]())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterDecoder(decodeLenientTime)
+		}()
+		go func() {
+			defer wg.Done()
+			var e Event
+			_ = json.Unmarshal([]byte(`{"name":"launch","at":"2024-09-04T00:00:00Z"}`), &e)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestDecodeIntegral(t *testing.T) {
+	req := require.New(t)
+
+	var p Person
+	err := json.Unmarshal([]byte(`{"name":"Saoirse","age":25.0}`), &p)
+	req.Error(err)
+
+	RegisterDecoder(DecodeIntegral[int])
+	defer decoders.Delete(reflect.TypeFor[int]())
+
+	for _, age := range []string{"25", "25.0", "2.5e1"} {
+		p = Person{}
+		err = json.Unmarshal([]byte(`{"name":"Saoirse","age":`+age+`}`), &p)
+		req.NoError(err, age)
+		req.NoError(Struct(&p))
+		req.Equal(25, p.Age.Value())
+	}
+
+	p = Person{}
+	err = json.Unmarshal([]byte(`{"name":"Saoirse","age":25.5}`), &p)
+	req.EqualError(err, "json: number 25.5 is not an integer")
+	req.False(p.Age.HasValue())
+
+	p = Person{}
+	err = json.Unmarshal([]byte(`{"name":"Saoirse","age":"25"}`), &p)
+	req.Error(err)
+
+	_, err = DecodeIntegral[int8]([]byte(`300`))
+	req.EqualError(err, "json: number 300 overflows int8")
+	_, err = DecodeIntegral[uint]([]byte(`-1`))
+	req.EqualError(err, "json: number -1 overflows uint")
+	_, err = DecodeIntegral[uint8]([]byte(`-1.0`))
+	req.Error(err)
+}
+
+func TestDecodeIntegralExact(t *testing.T) {
+	req := require.New(t)
+
+	var r Required[int64]
+	req.Error(json.Unmarshal([]byte(`1e3`), &r))
+	req.False(r.HasValue())
+	RegisterDecoder(DecodeIntegral[int64])
+	defer decoders.Delete(reflect.TypeFor[int64]())
+	req.NoError(json.Unmarshal([]byte(`1e3`), &r))
+	req.Equal(int64(1000), r.Value())
+
+	for _, c := range []struct {
+		in  string
+		out int64
+	}{
+		{"9223372036854775807", math.MaxInt64},
+		{"-9223372036854775808", math.MinInt64},
+		{"9223372036854775807.0", math.MaxInt64},
+		{"9223372036854775807e0", math.MaxInt64},
+		{"92233720368547758070e-1", math.MaxInt64},
+		{"1e3", 1000},
+		{"1E+3", 1000},
+		{"9007199254740993.0", 9007199254740993},
+	} {
+		n, err := DecodeIntegral[int64]([]byte(c.in))
+		req.NoError(err, c.in)
+		req.Equal(c.out, n, c.in)
+	}
+
+	for _, in := range []string{"9223372036854775808", "9223372036854775808.0", "1e19", "-9223372036854775809", "1e100000000"} {
+		_, err := DecodeIntegral[int64]([]byte(in))
+		req.EqualError(err, "json: number "+in+" overflows int64")
+	}
+	_, err := DecodeIntegral[int64]([]byte(`1e-3`))
+	req.EqualError(err, "json: number 1e-3 is not an integer")
+
+	u, err := DecodeIntegral[uint64]([]byte(`18446744073709551615`))
+	req.NoError(err)
+	req.Equal(uint64(math.MaxUint64), u)
+	_, err = DecodeIntegral[uint64]([]byte(`1.8446744073709551616e19`))
+	req.EqualError(err, "json: number 1.8446744073709551616e19 overflows uint64")
+}
+
+type Invoice struct {
+	Number Required[string]          `json:"number"`
+	Total  Required[decimal.Decimal] `json:"total"`
+}
+
+func TestDecimal(t *testing.T) {
+	req := require.New(t)
+
+	for _, total := range []string{`12.50`, `"12.50"`} {
+		var inv Invoice
+		req.NoError(ParseBytes([]byte(`{"number":"2024-001","total":`+total+`}`), &inv), total)
+		req.True(decimal.RequireFromString("12.5").Equal(inv.Total.Get()), total)
+	}
+
+	var inv Invoice
+	err := ParseBytes([]byte(`{"number":"2024-001"}`), &inv)
+	req.EqualError(err, "field 'Total' in 'validate.Invoice' is required")
+
+	// decimal rejects empty strings on its own
+	inv = Invoice{}
+	err = ParseBytes([]byte(`{"number":"2024-001","total":""}`), &inv)
+	req.Error(err)
+	req.NotErrorIs(err, ErrRequired)
+
+	RegisterDecoder(DecodeEmptyAsAbsent[decimal.Decimal])
+	defer decoders.Delete(reflect.TypeFor[decimal.Decimal]())
+
+	inv = Invoice{}
+	err = ParseBytes([]byte(`{"number":"2024-001","total":""}`), &inv)
+	req.EqualError(err, "field 'Total' in 'validate.Invoice' is required")
+	req.True(inv.Total.Touched())
+	req.False(inv.Total.HasValue())
+
+	inv = Invoice{}
+	req.NoError(ParseBytes([]byte(`{"number":"2024-001","total":"0.10"}`), &inv))
+	req.True(decimal.RequireFromString("0.1").Equal(inv.Total.Get()))
+
+	// an explicit null is a value as usual
+	inv = Invoice{}
+	req.NoError(ParseBytes([]byte(`{"number":"2024-001","total":null}`), &inv))
+	req.True(inv.Total.WasNull())
+}
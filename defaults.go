@@ -0,0 +1,50 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// FillDefaults sets the fields of type [Required] of the structure obj points to which have no value
+// to the defaults given by their `default` tags, e.g. `default:"42"`, and marks them as valid.
+// Defaults of fields of string underlying types are taken verbatim, the others are parsed as JSON.
+// The sources of the filled values are [SourceDefault].
+func FillDefaults(obj interface{}) error {
+	v, err := structPointer(obj)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, f := range reflect.VisibleFields(v.Type()) {
+		def, ok := f.Tag.Lookup("default")
+		if !ok || !f.IsExported() || !isRequiredField(f.Type) {
+			continue
+		}
+		fv, err := fieldByIndexAlloc(v, f.Index)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		x, _ := requiredAt(fv, true)
+		if x.HasValue() {
+			continue
+		}
+		if err := writable(x); err != nil {
+			errs = append(errs, fmt.Errorf("field '%s' in '%s' %w", f.Name, v.Type(), err))
+			continue
+		}
+		if t := x.RequiredType(); t.Kind() == reflect.String {
+			x.SettableValue().Set(reflect.ValueOf(def).Convert(t))
+		} else if err := json.Unmarshal([]byte(def), x.Ptr()); err != nil {
+			errs = append(errs, fmt.Errorf("%w: default %q of field '%s' in '%s': %w", ErrBadType, def, f.Name, v.Type(), err))
+			continue
+		}
+		x.SetValid(true)
+		if x, ok := x.(sourced); ok {
+			x.setSource(SourceDefault)
+		}
+	}
+	return errors.Join(errs...)
+}
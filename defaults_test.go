@@ -0,0 +1,66 @@
+package validate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Pagination struct {
+	Page    Required[int]    `json:"page" default:"1"`
+	PerPage Required[int]    `json:"perPage" default:"20"`
+	Sort    Required[string] `json:"sort" default:"created_at"`
+	Query   Required[string] `json:"query"`
+}
+
+func TestFillDefaults(t *testing.T) {
+	req := require.New(t)
+
+	var p Pagination
+	err := json.Unmarshal([]byte(`{"perPage":50,"query":"shoes"}`), &p)
+	req.NoError(err)
+	req.Error(Struct(&p))
+
+	req.NoError(FillDefaults(&p))
+	req.NoError(Struct(&p))
+	req.Equal(1, p.Page.Value())
+	req.Equal(SourceDefault, p.Page.Source())
+	req.Equal(50, p.PerPage.Value())
+	req.Equal(SourceJSON, p.PerPage.Source())
+	req.Equal("created_at", p.Sort.Value())
+	req.Equal(SourceDefault, p.Sort.Source())
+
+	p = Pagination{}
+	req.NoError(FillDefaults(&p))
+	err = Struct(&p)
+	req.Equal("field 'Query' in 'validate.Pagination' is required", err.Error())
+}
+
+type badDefault struct {
+	Count Required[int] `default:"many"`
+}
+
+func TestFillDefaultsMalformed(t *testing.T) {
+	req := require.New(t)
+
+	var b badDefault
+	err := FillDefaults(&b)
+	req.ErrorIs(err, ErrBadType)
+	req.False(b.Count.HasValue())
+
+	req.ErrorIs(FillDefaults(b), ErrBadType)
+}
+
+func TestFillDefaultsPointer(t *testing.T) {
+	req := require.New(t)
+
+	var p Patch
+	req.NoError(FillDefaults(&p))
+	req.Equal(7, p.Count.Value())
+	req.Equal(SourceDefault, p.Count.Source())
+
+	p.Count.Set(3)
+	req.NoError(FillDefaults(&p))
+	req.Equal(3, p.Count.Value())
+}
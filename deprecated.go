@@ -0,0 +1,20 @@
+package validate
+
+import "reflect"
+
+// Deprecated is a decorative type which signifies that a field in a request is deprecated,
+// e.g. because it has been superseded by another one. It unmarshals and tracks its presence like [Required],
+// but it is never required; if it has a value, [Struct] reports it to the callback set by [WithDeprecationWarning]
+// and validates the value like that of an optional [Required] field.
+type Deprecated[T any] struct {
+	Required[T]
+}
+
+func (d *Deprecated[T]) deprecated() {}
+
+// deprecatedIface is implemented by the instances of [Deprecated].
+type deprecatedIface interface {
+	deprecated()
+}
+
+var deprecatedIfaceType = reflect.TypeFor[deprecatedIface]()
@@ -0,0 +1,64 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Listener struct {
+	Host    Required[string]   `json:"host"`
+	Port    Required[int]      `json:"port"`
+	Address Deprecated[string] `json:"address" max:"16"`
+}
+
+func TestDeprecated(t *testing.T) {
+	req := require.New(t)
+
+	var warned []string
+	vd := New(WithDeprecationWarning(func(field string) { warned = append(warned, field) }))
+
+	var l Listener
+	req.NoError(vd.ParseBytes([]byte(`{"host":"localhost","port":8080,"address":"localhost:8080"}`), &l))
+	req.Equal([]string{"Address"}, warned)
+	req.Equal("localhost:8080", l.Address.Get())
+
+	// deprecated fields are not required
+	warned = nil
+	l = Listener{}
+	req.NoError(vd.ParseBytes([]byte(`{"host":"localhost","port":8080}`), &l))
+	req.Empty(warned)
+
+	// the validation proceeds as usual
+	l = Listener{}
+	err := vd.ParseBytes([]byte(`{"host":"localhost","address":"a.very.long.host.name:8080"}`), &l)
+	req.Equal([]string{"Address"}, warned)
+	req.Equal("field 'Port' in 'validate.Listener' is required\n"+
+		"field 'Address' in 'validate.Listener' is invalid: must have a length of at most 16", err.Error())
+
+	// without a callback deprecated fields are accepted silently
+	l = Listener{}
+	req.NoError(ParseBytes([]byte(`{"host":"localhost","port":8080,"address":"localhost:8080"}`), &l))
+}
+
+type LegacyListener struct {
+	Host Required[string]    `json:"host"`
+	Old  *Deprecated[string] `json:"old"`
+}
+
+func TestDeprecatedPointer(t *testing.T) {
+	req := require.New(t)
+
+	var warned []string
+	vd := New(WithDeprecationWarning(func(field string) { warned = append(warned, field) }))
+
+	var l LegacyListener
+	req.NoError(vd.ParseBytes([]byte(`{"host":"localhost"}`), &l))
+	req.Nil(l.Old)
+	req.Empty(warned)
+	_, missing := vd.FirstMissing(&l)
+	req.False(missing)
+
+	req.NoError(vd.ParseBytes([]byte(`{"host":"localhost","old":"x"}`), &l))
+	req.Equal([]string{"Old"}, warned)
+}
@@ -0,0 +1,51 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTooDeep indicates that a JSON expression is nested deeper than allowed by [WithMaxDepth].
+var ErrTooDeep = errors.New("JSON nesting too deep")
+
+// WithMaxDepth makes [Validator.Parse] and [Validator.ParseBytes] reject JSON expressions
+// whose objects and arrays are nested deeper than n levels with an error wrapping [ErrTooDeep].
+// The depth is checked before decoding; a top-level object has the depth 1.
+func WithMaxDepth(n int) Option {
+	return func(v *Validator) {
+		v.maxDepth = n
+	}
+}
+
+// checkDepth checks that the objects and arrays of the JSON expression b are nested at most limit levels deep.
+// Malformed expressions are left to be reported by the decoder.
+func checkDepth(b []byte, limit int) error {
+	var (
+		depth    int
+		inString bool
+		escaped  bool
+	)
+	for i, c := range b {
+		switch {
+		case escaped:
+			escaped = false
+		case inString:
+			switch c {
+			case '\\':
+				escaped = true
+			case '"':
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			depth++
+			if depth > limit {
+				return fmt.Errorf("%w: exceeds %d levels at offset %d", ErrTooDeep, limit, i)
+			}
+		case c == '}' || c == ']':
+			depth--
+		}
+	}
+	return nil
+}
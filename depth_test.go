@@ -0,0 +1,39 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Tree struct {
+	Name     Required[string] `json:"name"`
+	Children []Tree           `json:"children"`
+}
+
+func TestWithMaxDepth(t *testing.T) {
+	req := require.New(t)
+
+	vd := New(WithMaxDepth(4))
+
+	var tr Tree
+	req.NoError(vd.Parse(strings.NewReader(`{"name":"a","children":[{"name":"b{[[[["}]}`), &tr))
+	req.Equal("a", tr.Name.Value())
+
+	nested := `{"name":"a","children":[` + strings.Repeat(`{"name":"x","children":[`, 100) + strings.Repeat(`]}`, 100) + `]}`
+	tr = Tree{}
+	err := vd.Parse(strings.NewReader(nested), &tr)
+	req.ErrorIs(err, ErrTooDeep)
+	req.Equal("JSON nesting too deep: exceeds 4 levels at offset 48", err.Error())
+	req.False(tr.Name.HasValue())
+
+	tr = Tree{}
+	req.ErrorIs(vd.ParseBytes([]byte(nested), &tr), ErrTooDeep)
+
+	tr = Tree{}
+	req.NoError(ParseBytes([]byte(nested), &tr))
+
+	err = vd.ParseBytes([]byte(`{"name":"a\"{{{{{{"}`), &tr)
+	req.NoError(err)
+}
@@ -0,0 +1,45 @@
+package validate
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Checker is implemented by required-like types which constrain their values beyond presence.
+// [Struct] calls Check on such fields if they have a value.
+type Checker interface {
+	Check() error
+}
+
+// RequiredEnum is a [Required] whose value must be one of a set of allowed values.
+// The allowed values must be provided by constructing the field with [NewRequiredEnum]
+// before it is unmarshalled into.
+type RequiredEnum[T comparable] struct {
+	Required[T]
+	allowed []T
+}
+
+var (
+	_ RequiredIface = (*RequiredEnum[int])(nil)
+	_ Checker       = (*RequiredEnum[int])(nil)
+)
+
+// NewRequiredEnum creates an instance without a value accepting the provided values.
+func NewRequiredEnum[T comparable](allowed ...T) RequiredEnum[T] {
+	return RequiredEnum[T]{allowed: allowed}
+}
+
+// Allowed returns the allowed values.
+func (r *RequiredEnum[T]) Allowed() []T { return r.allowed }
+
+// Check checks that the underlying value is one of the allowed values.
+// An instance not constructed with [NewRequiredEnum], having no allowed values, fails with [ErrBadType].
+func (r *RequiredEnum[T]) Check() error {
+	if len(r.allowed) == 0 {
+		return fmt.Errorf("%w: no allowed values configured", ErrBadType)
+	}
+	if slices.Contains(r.allowed, r.value) {
+		return nil
+	}
+	return fmt.Errorf("%w: must be one of %v", ErrInvalid, r.allowed)
+}
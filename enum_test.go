@@ -0,0 +1,48 @@
+package validate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Ticket struct {
+	Title  Required[string]     `json:"title"`
+	Status RequiredEnum[string] `json:"status"`
+}
+
+func newTicket() Ticket {
+	return Ticket{Status: NewRequiredEnum("open", "closed")}
+}
+
+func TestRequiredEnum(t *testing.T) {
+	req := require.New(t)
+
+	tk := newTicket()
+	err := json.Unmarshal([]byte(`{"title":"Bug","status":"open"}`), &tk)
+	req.NoError(err)
+	req.NoError(Struct(&tk))
+	req.Equal("open", tk.Status.Value())
+
+	tk = newTicket()
+	err = json.Unmarshal([]byte(`{"title":"Bug","status":"pending"}`), &tk)
+	req.NoError(err)
+	err = Struct(&tk)
+	req.ErrorIs(err, ErrInvalid)
+	req.Equal("field 'Status' in 'validate.Ticket' is invalid: must be one of [open closed]", err.Error())
+
+	tk = newTicket()
+	err = json.Unmarshal([]byte(`{"title":"Bug"}`), &tk)
+	req.NoError(err)
+	err = Struct(&tk)
+	req.ErrorIs(err, ErrRequired)
+	req.Equal("field 'Status' in 'validate.Ticket' is required", err.Error())
+
+	tk = Ticket{}
+	err = json.Unmarshal([]byte(`{"title":"Bug","status":"open"}`), &tk)
+	req.NoError(err)
+	err = Struct(&tk)
+	req.ErrorIs(err, ErrBadType)
+	req.Equal("field 'Status' in 'validate.Ticket' is invalid: bad type: no allowed values configured", err.Error())
+}
@@ -0,0 +1,70 @@
+package validate
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// ParseEnv assigns environment variables to the fields of type [Required] of the structure obj points to
+// and validates it. The variables are named by the prefix followed by the `env` tags of the fields or,
+// without the tags, by the field names in upper snake case, e.g. `APP_DATABASE_URL` for the field `DatabaseURL`
+// with the prefix `APP_`. Variables which cannot be converted to the types of their fields are reported
+// as [ErrInvalid] along with the validation errors of the other fields.
+func ParseEnv(prefix string, obj interface{}) error {
+	return defaultValidator.ParseEnv(prefix, obj)
+}
+
+// ParseEnv is like [ParseEnv] but validates the object with the validator.
+func (vd *Validator) ParseEnv(prefix string, obj interface{}) error {
+	v, err := structPointer(obj)
+	if err != nil {
+		return err
+	}
+	failed := make(map[string]error)
+	for _, f := range reflect.VisibleFields(v.Type()) {
+		if !f.IsExported() || !isRequiredField(f.Type) {
+			continue
+		}
+		name := prefix + envName(f)
+		s, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		fv, err := fieldByIndexAlloc(v, f.Index)
+		if err != nil {
+			return err
+		}
+		x, _ := requiredAt(fv, true)
+		if err := vd.assignValues(x, []string{s}); err != nil {
+			failed[vd.fieldName(f)] = fmt.Errorf("%w: environment variable %s: %w", ErrInvalid, name, err)
+		}
+	}
+	return vd.structAfter(obj, failed)
+}
+
+// envName returns the name of the environment variable of the field without a prefix.
+func envName(f reflect.StructField) string {
+	if name := f.Tag.Get("env"); name != "" {
+		return name
+	}
+	return upperSnake(f.Name)
+}
+
+// upperSnake converts a Go identifier to upper snake case, keeping acronyms together,
+// e.g. `APIKey` to `API_KEY`.
+func upperSnake(s string) string {
+	rs := []rune(s)
+	var b strings.Builder
+	for i, r := range rs {
+		if i > 0 && unicode.IsUpper(r) &&
+			(!unicode.IsUpper(rs[i-1]) || i+1 < len(rs) && unicode.IsLower(rs[i+1])) &&
+			rs[i-1] != '_' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,88 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type ServerConfig struct {
+	DatabaseURL Required[string]  `json:"databaseUrl"`
+	Port        Required[int]     `json:"port"`
+	Debug       Required[bool]    `json:"debug" env:"VERBOSE"`
+	APIKey      Required[string]  `json:"apiKey"`
+	Ratio       Required[float64] `json:"ratio"`
+}
+
+func TestParseEnv(t *testing.T) {
+	req := require.New(t)
+
+	t.Setenv("APP_DATABASE_URL", "postgres://localhost/app")
+	t.Setenv("APP_PORT", "8080")
+	t.Setenv("APP_VERBOSE", "true")
+	t.Setenv("APP_API_KEY", "secret")
+	t.Setenv("APP_RATIO", "0.5")
+
+	var c ServerConfig
+	req.NoError(ParseEnv("APP_", &c))
+	req.Equal("postgres://localhost/app", c.DatabaseURL.Value())
+	req.Equal(8080, c.Port.Value())
+	req.Equal(true, c.Debug.Value())
+	req.Equal("secret", c.APIKey.Value())
+	req.Equal(0.5, c.Ratio.Value())
+}
+
+func TestParseEnvErrors(t *testing.T) {
+	req := require.New(t)
+
+	t.Setenv("SVC_PORT", "http")
+	t.Setenv("SVC_VERBOSE", "false")
+	t.Setenv("SVC_RATIO", "0.25")
+
+	var c ServerConfig
+	err := ParseEnv("SVC_", &c)
+	req.ErrorIs(err, ErrInvalid)
+	req.Equal(`field 'DatabaseURL' in 'validate.ServerConfig' is required
+field 'Port' in 'validate.ServerConfig' is invalid: environment variable SVC_PORT: strconv.ParseInt: parsing "http": invalid syntax
+field 'APIKey' in 'validate.ServerConfig' is required`, err.Error())
+	req.Equal(false, c.Debug.Value())
+	req.True(c.Debug.HasValue())
+}
+
+func TestParseEnvFieldNames(t *testing.T) {
+	req := require.New(t)
+
+	t.Setenv("SVC_DATABASE_URL", "postgres://localhost/app")
+	t.Setenv("SVC_PORT", "http")
+	t.Setenv("SVC_API_KEY", "secret")
+
+	var c ServerConfig
+	err := New(WithLowerCamelNames()).ParseEnv("SVC_", &c)
+	verr := err.(*ValidationError)
+	req.Len(verr.Fields, 3)
+	req.Equal("port", verr.Fields[0].Field)
+	req.ErrorIs(verr.Fields[0].Err, ErrInvalid)
+	req.Equal("debug", verr.Fields[1].Field)
+	req.Equal("ratio", verr.Fields[2].Field)
+}
+
+func TestUpperSnake(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal("DATABASE_URL", upperSnake("DatabaseURL"))
+	req.Equal("API_KEY", upperSnake("APIKey"))
+	req.Equal("PORT", upperSnake("Port"))
+	req.Equal("HTTP2_ENABLED", upperSnake("HTTP2Enabled"))
+	req.Equal("MAX_CONNS", upperSnake("Max_Conns"))
+}
+
+func TestParseEnvPointers(t *testing.T) {
+	req := require.New(t)
+
+	t.Setenv("PATCH_COUNT", "3")
+	t.Setenv("PATCH_NOTE", "x")
+
+	var p Patch
+	req.NoError(ParseEnv("PATCH_", &p))
+	req.Equal(3, p.Count.Value())
+}
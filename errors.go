@@ -0,0 +1,73 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError describes a single misbehaving field.
+type FieldError struct {
+	// Type is the name of the validated structure type.
+	Type string
+	// Field is the path to the field, e.g. `Items[2].SKU`.
+	Field string
+	// Pointer is the RFC 6901 JSON pointer to the field's value, e.g. `/items/2/sku`.
+	Pointer string
+	// Err is the cause of the error, e.g. [ErrRequired].
+	Err error
+	// structure is set if the error has been returned by a validator registered with [RegisterStructValidator]
+	structure bool
+}
+
+func (e *FieldError) Error() string {
+	if e.structure {
+		if e.Field == "" {
+			return e.Err.Error()
+		}
+		return fmt.Sprintf("%s: %v", e.Field, e.Err)
+	}
+	return fmt.Sprintf("field '%s' in '%s' %v", e.Field, e.Type, e.Err)
+}
+
+// Unwrap returns the cause of the error.
+func (e *FieldError) Unwrap() error { return e.Err }
+
+// ValidationError is a multi-error listing all the misbehaving fields of a structure.
+// The fields are listed in the order of their declaration, depth first, with promoted fields in place
+// of the embedded structures, elements of slices and arrays by their indices and values of maps by their sorted keys.
+// The order is stable and does not depend on options or caching; the values which [ParseValues] and [ParseEnv]
+// fail to convert are reported in place of the fields they were meant for.
+//
+// The errors returned by the validators registered with [RegisterStructValidator] are listed after the fields
+// of the structures they pertain to, as [FieldError] instances whose Field is the path of the structure,
+// empty for the validated structure itself, and whose Err is the returned error.
+type ValidationError struct {
+	Fields []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	var sb strings.Builder
+	for i, f := range e.Fields {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(f.Error())
+	}
+	return sb.String()
+}
+
+// Unwrap returns the field errors.
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Fields))
+	for i, f := range e.Fields {
+		errs[i] = f
+	}
+	return errs
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// pointerToken escapes a reference token of a JSON pointer.
+func pointerToken(s string) string {
+	return pointerEscaper.Replace(s)
+}
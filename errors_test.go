@@ -0,0 +1,114 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errBlockedDomain = errors.New("domain is blocked")
+
+type Mailbox struct {
+	Required[string]
+}
+
+func (m *Mailbox) Check() error {
+	if m.value == "spam@example.com" {
+		return errBlockedDomain
+	}
+	return nil
+}
+
+type Signup struct {
+	Name  Required[string] `json:"name"`
+	Email Mailbox          `json:"email"`
+	Age   Required[int]    `json:"age"`
+}
+
+func TestValidationErrorTraversal(t *testing.T) {
+	req := require.New(t)
+
+	var s Signup
+	err := json.Unmarshal([]byte(`{"email":"spam@example.com"}`), &s)
+	req.NoError(err)
+
+	err = Struct(&s)
+	req.Len(err.(interface{ Unwrap() []error }).Unwrap(), 3)
+	req.ErrorIs(err, ErrRequired)
+	req.ErrorIs(err, ErrInvalid)
+	req.ErrorIs(err, errBlockedDomain)
+	req.NotErrorIs(err, ErrForbidden)
+
+	var ferr *FieldError
+	req.ErrorAs(err, &ferr)
+	req.Equal("Name", ferr.Field)
+
+	for _, e := range err.(*ValidationError).Unwrap() {
+		req.ErrorAs(e, &ferr)
+		if errors.Is(e, errBlockedDomain) {
+			req.Equal("Email", ferr.Field)
+			req.Equal("field 'Email' in 'validate.Signup' is invalid: domain is blocked", e.Error())
+		}
+	}
+
+	// the traversal works through further wrapping
+	wrapped := errors.Join(errors.New("request rejected"), err)
+	req.ErrorIs(wrapped, errBlockedDomain)
+	var verr *ValidationError
+	req.ErrorAs(wrapped, &verr)
+	req.Len(verr.Fields, 3)
+}
+
+type Questionnaire struct {
+	Zeta    Required[string] `json:"zeta"`
+	Address `json:"-"`
+	Alpha   Required[int]            `json:"alpha"`
+	Answers map[string]Item          `json:"answers"`
+	Notes   []Item                   `json:"notes"`
+	Middle  Required[string]         `json:"middle"`
+	Billing Required[Address]        `json:"billing"`
+	Extra   map[int]Required[string] `json:"extra"`
+}
+
+func TestFieldErrorOrder(t *testing.T) {
+	req := require.New(t)
+
+	const expected = `field 'Zeta' in 'validate.Questionnaire' is required
+field 'Street' in 'validate.Questionnaire' is required
+field 'Zip' in 'validate.Questionnaire' is required
+field 'Alpha' in 'validate.Questionnaire' is required
+field 'Answers["a"].SKU' in 'validate.Questionnaire' is required
+field 'Answers["b"].SKU' in 'validate.Questionnaire' is required
+field 'Answers["c"].SKU' in 'validate.Questionnaire' is required
+field 'Notes[0].SKU' in 'validate.Questionnaire' is required
+field 'Notes[1].SKU' in 'validate.Questionnaire' is required
+field 'Middle' in 'validate.Questionnaire' is required
+field 'Billing.Zip' in 'validate.Questionnaire' is required
+field 'Extra[1]' in 'validate.Questionnaire' is required
+field 'Extra[10]' in 'validate.Questionnaire' is required`
+
+	payload := []byte(`{"answers":{"c":{},"a":{},"b":{}},"notes":[{},{}],"billing":{"street":"Main"}}`)
+	for _, vd := range []*Validator{defaultValidator, New(WithCache(8)), New(WithZeroAsMissing())} {
+		for range 10 {
+			var q Questionnaire
+			req.NoError(json.Unmarshal(payload, &q))
+			q.Extra = map[int]Required[string]{10: {}, 1: {}}
+			err := vd.Struct(&q)
+			req.Equal(expected, err.Error())
+		}
+	}
+
+	invalidAlpha := strings.Replace(expected, "field 'Alpha' in 'validate.Questionnaire' is required",
+		`field 'Alpha' in 'validate.Questionnaire' is invalid: strconv.ParseInt: parsing "x": invalid syntax`, 1)
+	for _, vd := range []*Validator{defaultValidator, New(WithZeroAsMissing())} {
+		var q Questionnaire
+		req.NoError(json.Unmarshal(payload, &q))
+		q.Extra = map[int]Required[string]{10: {}, 1: {}}
+		err := vd.ParseValues(url.Values{"alpha": {"x"}}, &q)
+		req.Equal(invalidAlpha, err.Error())
+	}
+}
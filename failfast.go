@@ -0,0 +1,86 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// ParseFailFast parses a JSON object into the provided struct instance member by member and validates it.
+// Unlike [Parse], it checks the constraints of each top-level field of type [Required] as soon as the field
+// has been decoded, i.e. the rules given by its tags and [Checker.Check], and stops reading at the first
+// violation, which is returned as a [ValidationError]. The presence of the required fields is checked,
+// and nested structures are validated, once the whole object has been decoded.
+func ParseFailFast(r io.Reader, obj interface{}) error {
+	return defaultValidator.ParseFailFast(r, obj)
+}
+
+// ParseFailFast is like [ParseFailFast] but validates the object with the validator.
+func (vd *Validator) ParseFailFast(r io.Reader, obj interface{}) error {
+	v, err := structPointer(obj)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("%w: expected a JSON object, got %v", ErrBadType, tok)
+	}
+	fields := jsonFields(v.Type())
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key := tok.(string)
+		f, ok := matchField(fields, key)
+		if !ok {
+			if vd.unknownField != nil {
+				vd.unknownField(key)
+			}
+			var skipped json.RawMessage
+			if err := dec.Decode(&skipped); err != nil {
+				return err
+			}
+			continue
+		}
+		fv, err := fieldByIndexAlloc(v, f.index)
+		if err != nil {
+			return err
+		}
+		if err := dec.Decode(fv.Addr().Interface()); err != nil {
+			return err
+		}
+		if err := vd.checkDecoded(v.Type(), fv, f); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	return vd.Struct(obj)
+}
+
+// checkDecoded checks the constraints of the decoded field fv of the structure type t.
+func (vd *Validator) checkDecoded(t reflect.Type, fv reflect.Value, f jsonField) error {
+	sf := t.FieldByIndex(f.index)
+	name := vd.fieldName(sf)
+	x, ok := requiredAt(fv, false)
+	if !ok || !x.HasValue() || vd.skip[name] {
+		return nil
+	}
+	err := checkRules(sf.Tag, x.SettableValue())
+	if c, ok := x.(Checker); ok && err == nil {
+		if err = c.Check(); err != nil {
+			err = invalid(err)
+		}
+	}
+	if err != nil {
+		return fieldErrors([]*FieldError{{Type: vd.nameType(t), Field: name, Pointer: "/" + pointerToken(jsonName(sf)), Err: err}})
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package validate
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestParseFailFast(t *testing.T) {
+	req := require.New(t)
+
+	tk := newTicket()
+	req.NoError(ParseFailFast(strings.NewReader(`{"status":"open","title":"Printer on fire","extra":[1,2]}`), &tk))
+	req.Equal("open", tk.Status.Value())
+	req.Equal("Printer on fire", tk.Title.Value())
+
+	tk = newTicket()
+	err := ParseFailFast(strings.NewReader(`{"status":"closed"}`), &tk)
+	req.Equal("field 'Title' in 'validate.Ticket' is required", err.Error())
+
+	body := `{"status":"lost","title":"` + strings.Repeat("a", 1<<20) + `"}`
+	r := &countingReader{r: strings.NewReader(body)}
+	tk = newTicket()
+	err = ParseFailFast(r, &tk)
+	req.ErrorIs(err, ErrInvalid)
+	req.Equal("field 'Status' in 'validate.Ticket' is invalid: must be one of [open closed]", err.Error())
+	req.Less(r.n, len(body)/16)
+	req.False(tk.Title.HasValue())
+
+	tk = newTicket()
+	err = New(WithLowerCamelNames()).ParseFailFast(strings.NewReader(`{"status":"lost"}`), &tk)
+	req.Equal("field 'status' in 'validate.Ticket' is invalid: must be one of [open closed]", err.Error())
+
+	tk = newTicket()
+	err = New(WithLowerCamelNames(), WithSkipFields("status", "title")).ParseFailFast(strings.NewReader(`{"status":"lost"}`), &tk)
+	req.NoError(err)
+
+	err = ParseFailFast(strings.NewReader(`[]`), &tk)
+	req.ErrorIs(err, ErrBadType)
+}
@@ -0,0 +1,106 @@
+package validate
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// jsonField describes a structure field as seen by encoding/json.
+type jsonField struct {
+	name    string
+	index   []int
+	aliases []string
+}
+
+// jsonFields returns the fields of the structure type t which encoding/json decodes into,
+// along with the JSON keys they are decoded from.
+func jsonFields(t reflect.Type) []jsonField {
+	var (
+		fields []jsonField
+		named  [][]int
+	)
+outer:
+	for _, f := range reflect.VisibleFields(t) {
+		for _, idx := range named {
+			if len(f.Index) > len(idx) && slices.Equal(f.Index[:len(idx)], idx) {
+				continue outer
+			}
+		}
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct && name == "" {
+				continue
+			}
+			if !f.IsExported() {
+				continue
+			}
+			if name != "" {
+				named = append(named, f.Index)
+			}
+		}
+		if name == "" {
+			name = f.Name
+		}
+		var aliases []string
+		if tag := f.Tag.Get("jsonalt"); tag != "" {
+			aliases = strings.Split(tag, ",")
+		}
+		fields = append(fields, jsonField{name: name, index: f.Index, aliases: aliases})
+	}
+	return fields
+}
+
+// matchField returns the field into which encoding/json decodes the value under the provided key.
+// Like encoding/json, it prefers an exact match and falls back to a case-insensitive one.
+func matchField(fields []jsonField, key string) (jsonField, bool) {
+	for _, f := range fields {
+		if f.name == key {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, key) {
+			return f, true
+		}
+	}
+	return jsonField{}, false
+}
+
+// matchAlias returns the field which has the provided key among its alternative names.
+// Like [matchField], it prefers an exact match and falls back to a case-insensitive one.
+func matchAlias(fields []jsonField, key string) (jsonField, bool) {
+	for _, f := range fields {
+		if slices.Contains(f.aliases, key) {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if slices.ContainsFunc(f.aliases, func(alias string) bool { return strings.EqualFold(alias, key) }) {
+			return f, true
+		}
+	}
+	return jsonField{}, false
+}
+
+// aliasedTypes caches whether structure types have fields with alternative names.
+var aliasedTypes sync.Map // map[reflect.Type]*layout[bool]
+
+// hasAliases tells whether any field of the structure type t has alternative names.
+func hasAliases(t reflect.Type) bool {
+	return cachedLayout(&aliasedTypes, t, func(t reflect.Type) bool {
+		return slices.ContainsFunc(jsonFields(t), func(f jsonField) bool { return len(f.aliases) > 0 })
+	})
+}
@@ -0,0 +1,69 @@
+package validate
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Named struct {
+	ID Required[string] `json:"id"`
+}
+
+type Account struct {
+	Named
+	Inner   Named            `json:"inner"`
+	Email   Required[string] `json:"email,omitempty"`
+	Balance int
+	Secret  string `json:"-"`
+	private int
+}
+
+func TestJSONFields(t *testing.T) {
+	req := require.New(t)
+
+	fields := jsonFields(reflect.TypeFor[Account]())
+	var names []string
+	for _, f := range fields {
+		names = append(names, f.name)
+	}
+	req.Equal([]string{"id", "inner", "email", "Balance"}, names)
+}
+
+func TestMatchFieldCaseInsensitive(t *testing.T) {
+	req := require.New(t)
+
+	fields := jsonFields(reflect.TypeFor[Person]())
+	for _, key := range []string{"age", "Age", "AGE", "aGe"} {
+		f, ok := matchField(fields, key)
+		req.True(ok, key)
+		req.Equal("age", f.name)
+
+		var p Person
+		err := json.Unmarshal([]byte(`{"`+key+`":25}`), &p)
+		req.NoError(err)
+		req.True(reflect.ValueOf(&p).Elem().FieldByIndex(f.index).Addr().Interface().(RequiredIface).HasValue(), key)
+	}
+
+	_, ok := matchField(fields, "height")
+	req.False(ok)
+}
+
+type Cased struct {
+	Lower Required[int] `json:"key"`
+	Upper Required[int] `json:"KEY"`
+}
+
+func TestMatchFieldPrefersExact(t *testing.T) {
+	req := require.New(t)
+
+	fields := jsonFields(reflect.TypeFor[Cased]())
+	f, ok := matchField(fields, "KEY")
+	req.True(ok)
+	req.Equal([]int{1}, f.index)
+	f, ok = matchField(fields, "Key")
+	req.True(ok)
+	req.Equal([]int{0}, f.index)
+}
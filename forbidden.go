@@ -0,0 +1,61 @@
+package validate
+
+import "reflect"
+
+// Forbidden is a decorative type which signifies that a field in a request must not be provided,
+// e.g. because it is set by the server. It unmarshals like [Required] so that its presence can be detected,
+// and [Struct] reports it if it has a value.
+type Forbidden[T any] struct {
+	Required[T]
+}
+
+func (f *Forbidden[T]) forbidden() {}
+
+// forbiddenIface is implemented by the instances of [Forbidden].
+type forbiddenIface interface {
+	HasValue() bool
+	forbidden()
+}
+
+var forbiddenIfaceType = reflect.TypeFor[forbiddenIface]()
+
+// isRequiredType tells whether t is a required-like type, that is, whether it implements [RequiredIface]
+// without being a [Forbidden], which embeds [Required] only to detect its presence.
+func isRequiredType(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	return pt.Implements(RequiredIfaceType) && !pt.Implements(forbiddenIfaceType)
+}
+
+// requiredValue returns x as a [RequiredIface] unless it is a [Forbidden].
+func requiredValue(x interface{}) (RequiredIface, bool) {
+	if _, ok := x.(forbiddenIface); ok {
+		return nil, false
+	}
+	r, ok := x.(RequiredIface)
+	return r, ok
+}
+
+// isRequiredField tells whether a field of type t holds a required-like value, directly or through a pointer.
+func isRequiredField(t reflect.Type) bool {
+	return isRequiredType(t) || t.Kind() == reflect.Pointer && isRequiredType(t.Elem())
+}
+
+// requiredAt returns the required-like value held by the addressable field fv, directly or through a pointer,
+// unless it is a [Forbidden]. A nil pointer is allocated if alloc is set and yields false otherwise.
+func requiredAt(fv reflect.Value, alloc bool) (RequiredIface, bool) {
+	if fv.Kind() == reflect.Pointer && isRequiredType(fv.Type().Elem()) {
+		if fv.IsNil() {
+			if !alloc || !fv.CanSet() {
+				return nil, false
+			}
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		return fv.Interface().(RequiredIface), true
+	}
+	return requiredValue(fv.Addr().Interface())
+}
+
+// isForbiddenType tells whether t is a [Forbidden] or a pointer to one.
+func isForbiddenType(t reflect.Type) bool {
+	return reflect.PointerTo(indirectType(t)).Implements(forbiddenIfaceType)
+}
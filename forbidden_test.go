@@ -0,0 +1,110 @@
+package validate
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type CreateUser struct {
+	ID        Forbidden[string] `json:"id"`
+	CreatedAt Forbidden[string] `json:"createdAt"`
+	Name      Required[string]  `json:"name"`
+}
+
+func TestForbidden(t *testing.T) {
+	req := require.New(t)
+
+	var u CreateUser
+	err := json.Unmarshal([]byte(`{"name":"Saoirse"}`), &u)
+	req.NoError(err)
+	req.NoError(Struct(&u))
+
+	u = CreateUser{}
+	err = json.Unmarshal([]byte(`{"id":"u1","name":"Saoirse"}`), &u)
+	req.NoError(err)
+	req.True(u.ID.HasValue())
+	err = Struct(&u)
+	req.ErrorIs(err, ErrForbidden)
+	req.Equal("field 'ID' in 'validate.CreateUser' must not be provided", err.Error())
+
+	u = CreateUser{}
+	err = json.Unmarshal([]byte(`{"id":null,"createdAt":"now"}`), &u)
+	req.NoError(err)
+	err = Struct(&u)
+	req.Equal("field 'ID' in 'validate.CreateUser' must not be provided\n"+
+		"field 'CreatedAt' in 'validate.CreateUser' must not be provided\n"+
+		"field 'Name' in 'validate.CreateUser' is required", err.Error())
+}
+
+type UpdateUser struct {
+	ID   *Forbidden[string] `json:"id"`
+	Name Required[string]   `json:"name"`
+}
+
+func TestForbiddenPointer(t *testing.T) {
+	req := require.New(t)
+
+	var u UpdateUser
+	req.NoError(json.Unmarshal([]byte(`{"name":"Saoirse"}`), &u))
+	req.Nil(u.ID)
+	req.NoError(Struct(&u))
+
+	u = UpdateUser{}
+	req.NoError(json.Unmarshal([]byte(`{"id":"u1","name":"Saoirse"}`), &u))
+	err := Struct(&u)
+	req.ErrorIs(err, ErrForbidden)
+	req.Equal("field 'ID' in 'validate.UpdateUser' must not be provided", err.Error())
+}
+
+type ServerManaged struct {
+	ID   Forbidden[string] `json:"id" default:"generated" env:"ID"`
+	Name Required[string]  `json:"name" default:"unnamed"`
+}
+
+func TestForbiddenHelpers(t *testing.T) {
+	req := require.New(t)
+
+	var s ServerManaged
+	req.NoError(json.Unmarshal([]byte(`{"id":"u1","name":"Saoirse"}`), &s))
+	req.Equal(map[string]interface{}{"name": "Saoirse"}, ToMap(&s))
+	req.Len(Fields(&s), 1)
+
+	var dst ServerManaged
+	req.NoError(Merge(&dst, &s))
+	req.False(dst.ID.HasValue())
+
+	err := SetField(&dst, "ID", "u2")
+	req.ErrorIs(err, ErrBadType)
+
+	dst = ServerManaged{}
+	req.NoError(FillDefaults(&dst))
+	req.False(dst.ID.HasValue())
+	req.Equal("unnamed", dst.Name.Get())
+
+	dst = ServerManaged{}
+	req.NoError(ParseValues(url.Values{"id": {"u3"}, "name": {"Saoirse"}}, &dst))
+	req.False(dst.ID.HasValue())
+
+	t.Setenv("APP_ID", "u4")
+	t.Setenv("APP_NAME", "Saoirse")
+	dst = ServerManaged{}
+	req.NoError(ParseEnv("APP_", &dst))
+	req.False(dst.ID.HasValue())
+
+	dst = ServerManaged{ID: Forbidden[string]{}}
+	dst.ID.value = "u5"
+	MarkPresentNonZero(&dst)
+	req.False(dst.ID.HasValue())
+
+	// forbidden elements of containers are reported as forbidden
+	var c struct {
+		IDs []Forbidden[string] `json:"ids"`
+	}
+	req.NoError(json.Unmarshal([]byte(`{"ids":["u1"]}`), &c))
+	err = Struct(&c)
+	req.ErrorIs(err, ErrForbidden)
+	req.NotErrorIs(err, ErrRequired)
+}
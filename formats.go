@@ -0,0 +1,42 @@
+package validate
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+)
+
+// RequiredEmail is a [Required] string which must be an email address without a display name,
+// e.g. `jane@example.com`.
+type RequiredEmail struct {
+	Required[string]
+}
+
+// RequiredUUID is a [Required] string which must be a UUID in the canonical textual form,
+// e.g. `123e4567-e89b-12d3-a456-426614174000`. Both lowercase and uppercase digits are accepted.
+type RequiredUUID struct {
+	Required[string]
+}
+
+var (
+	_ Checker = (*RequiredEmail)(nil)
+	_ Checker = (*RequiredUUID)(nil)
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Check checks that the underlying value is an email address.
+func (r *RequiredEmail) Check() error {
+	if addr, err := mail.ParseAddress(r.value); err != nil || addr.Name != "" || addr.Address != r.value {
+		return fmt.Errorf("%w: must be an email address", ErrInvalid)
+	}
+	return nil
+}
+
+// Check checks that the underlying value is a UUID.
+func (r *RequiredUUID) Check() error {
+	if !uuidPattern.MatchString(r.value) {
+		return fmt.Errorf("%w: must be a UUID", ErrInvalid)
+	}
+	return nil
+}
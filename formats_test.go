@@ -0,0 +1,45 @@
+package validate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Invitation struct {
+	Email   RequiredEmail `json:"email"`
+	TeamID  RequiredUUID  `json:"teamId"`
+	Comment Required[string]
+}
+
+func TestRequiredFormats(t *testing.T) {
+	req := require.New(t)
+
+	var inv Invitation
+	err := json.Unmarshal([]byte(`{"email":"jane@example.com","teamId":"123E4567-e89b-12d3-a456-426614174000","Comment":"hi"}`), &inv)
+	req.NoError(err)
+	req.NoError(Struct(&inv))
+	req.Equal("jane@example.com", inv.Email.Value())
+
+	for _, email := range []string{"jane", "Jane <jane@example.com>", " jane@example.com", "jane@"} {
+		inv.Email.Set(email)
+		err = Struct(&inv)
+		req.ErrorIs(err, ErrInvalid, email)
+		req.Equal("field 'Email' in 'validate.Invitation' is invalid: must be an email address", err.Error())
+	}
+	inv.Email.Set("jane@example.com")
+
+	for _, id := range []string{"123e4567e89b12d3a456426614174000", "123e4567-e89b-12d3-a456-42661417400g", "{123e4567-e89b-12d3-a456-426614174000}"} {
+		inv.TeamID.Set(id)
+		err = Struct(&inv)
+		req.Equal("field 'TeamID' in 'validate.Invitation' is invalid: must be a UUID", err.Error(), id)
+	}
+
+	inv = Invitation{}
+	err = json.Unmarshal([]byte(`{"Comment":"hi"}`), &inv)
+	req.NoError(err)
+	err = Struct(&inv)
+	req.Equal(`field 'Email' in 'validate.Invitation' is required
+field 'TeamID' in 'validate.Invitation' is required`, err.Error())
+}
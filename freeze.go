@@ -0,0 +1,72 @@
+package validate
+
+import (
+	"errors"
+	"reflect"
+)
+
+// ErrFrozen indicates an attempt to modify a field frozen by [Freeze].
+var ErrFrozen = errors.New("is frozen")
+
+// freezable is implemented by required-like types which can be made read-only.
+type freezable interface {
+	freeze()
+	isFrozen() bool
+}
+
+var _ freezable = (*Required[int])(nil)
+
+func (r *Required[T]) freeze() { r.frozen = true }
+
+func (r *Required[T]) isFrozen() bool { return r.frozen }
+
+// writable returns [ErrFrozen] if x has been frozen.
+func writable(x interface{}) error {
+	if f, ok := x.(freezable); ok && f.isFrozen() {
+		return ErrFrozen
+	}
+	return nil
+}
+
+// Freeze makes the fields of type [Required] of the structure obj points to read-only,
+// including those of nested structures held in fields and values of [Required] fields.
+// The values of frozen fields can still be read, but [Required.Set], [Required.UnmarshalJSON]
+// and [Required.Scan] fail with [ErrFrozen] and so do [SetField], [Merge] and the other helpers of this package
+// which assign to fields. Freezing cannot be undone; copies of frozen fields are frozen too.
+func Freeze(obj interface{}) error {
+	v, err := structPointer(obj)
+	if err != nil {
+		return err
+	}
+	freezeStruct(v)
+	return nil
+}
+
+// freezeStruct freezes the fields of the addressable structure v.
+func freezeStruct(v reflect.Value) {
+	for _, f := range reflect.VisibleFields(v.Type()) {
+		if !f.IsExported() {
+			continue
+		}
+		fv, err := v.FieldByIndexErr(f.Index)
+		if err != nil {
+			continue
+		}
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		x, ok := fv.Addr().Interface().(freezable)
+		switch {
+		case ok:
+			x.freeze()
+			if r, ok := x.(RequiredIface); ok && r.Kind() == reflect.Struct {
+				freezeStruct(r.SettableValue())
+			}
+		case f.Type.Kind() == reflect.Struct && !f.Anonymous:
+			freezeStruct(fv)
+		}
+	}
+}
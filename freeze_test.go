@@ -0,0 +1,61 @@
+package validate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreeze(t *testing.T) {
+	req := require.New(t)
+
+	var o Order
+	err := json.Unmarshal([]byte(`{"id":"o1","address":{"street":"Main","zip":"12345"},"billing":{"street":"High","zip":"54321"}}`), &o)
+	req.NoError(err)
+	req.NoError(Struct(&o))
+	req.NoError(Freeze(&o))
+
+	req.ErrorIs(o.ID.Set("o2"), ErrFrozen)
+	req.Equal("o1", o.ID.Value())
+	req.True(o.ID.HasValue())
+	o.ID.SetValid(false)
+	req.True(o.ID.HasValue())
+
+	req.ErrorIs(json.Unmarshal([]byte(`{"id":"o3"}`), &o), ErrFrozen)
+	req.Equal("o1", o.ID.Value())
+	req.ErrorIs(o.Address.Zip.Set("00000"), ErrFrozen)
+	req.ErrorIs(o.Billing.value.Zip.Set("00000"), ErrFrozen)
+	req.ErrorIs(o.ID.Scan("o4"), ErrFrozen)
+
+	err = SetField(&o, "ID", "o5")
+	req.ErrorIs(err, ErrFrozen)
+	req.Equal("field 'ID' in 'validate.Order' is frozen", err.Error())
+
+	var src Order
+	req.NoError(src.ID.Set("o6"))
+	req.ErrorIs(Merge(&o, &src), ErrFrozen)
+	req.Equal("o1", o.ID.Value())
+	req.NoError(Struct(&o))
+
+	req.ErrorIs(Freeze(o), ErrBadType)
+}
+
+func TestFreezePointers(t *testing.T) {
+	req := require.New(t)
+
+	var p Patch
+	req.NoError(json.Unmarshal([]byte(`{"count":3,"note":"x"}`), &p))
+	req.NoError(Freeze(&p))
+	req.ErrorIs(p.Count.Set(9), ErrFrozen)
+	req.ErrorIs(json.Unmarshal([]byte(`{"count":4}`), &p), ErrFrozen)
+	req.Equal(3, p.Count.Value())
+
+	var c Comment
+	req.NoError(json.Unmarshal([]byte(`{"text":"hi","user":"id123"}`), &c))
+	req.NoError(Freeze(&c))
+	c.User.SetValid(false)
+	req.True(c.User.HasValue())
+	req.Equal(1, c.User.Variant())
+	req.Equal("id123", c.User.Value())
+}
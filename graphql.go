@@ -0,0 +1,17 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// FromGraphQLInput populates the provided struct instance from a GraphQL input object, as received
+// e.g. by gqlgen resolvers, and validates it. The camelCase field names of the input are matched
+// against the JSON keys of the fields case-insensitively, nested input objects included.
+func FromGraphQLInput(obj interface{}, input map[string]interface{}) error {
+	b, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	return Parse(bytes.NewReader(b), obj)
+}
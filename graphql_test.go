@@ -0,0 +1,40 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type CreateAccountInput struct {
+	AccountID   Required[string]
+	DisplayName Required[string]
+	Address     Required[Address] `json:"address"`
+	Tags        []string
+}
+
+func TestFromGraphQLInput(t *testing.T) {
+	req := require.New(t)
+
+	var in CreateAccountInput
+	err := FromGraphQLInput(&in, map[string]interface{}{
+		"accountId":   "acc-1",
+		"displayName": "Saoirse",
+		"address":     map[string]interface{}{"street": "Main", "zip": "12345"},
+		"tags":        []interface{}{"vip"},
+	})
+	req.NoError(err)
+	req.Equal("acc-1", in.AccountID.Value())
+	req.Equal("Saoirse", in.DisplayName.Value())
+	addr := in.Address.Value().(Address)
+	req.Equal("12345", addr.Zip.Value())
+	req.Equal([]string{"vip"}, in.Tags)
+
+	in = CreateAccountInput{}
+	err = FromGraphQLInput(&in, map[string]interface{}{
+		"accountId": "acc-1",
+		"address":   map[string]interface{}{"street": "Main"},
+	})
+	req.Equal("field 'DisplayName' in 'validate.CreateAccountInput' is required\n"+
+		"field 'Address.Zip' in 'validate.CreateAccountInput' is required", err.Error())
+}
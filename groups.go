@@ -0,0 +1,29 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTooFewPresent indicates that fewer fields of a group have values than required by [AtLeastN].
+var ErrTooFewPresent = errors.New("too few fields present")
+
+// AtLeastN checks that at least n of the fields of the group called name have values, e.g. that at least two
+// of three contact methods have been provided. It is meant to be called after [Struct] as an additional
+// validation step:
+//
+//	if err := validate.AtLeastN("contact methods", 2, &c.Email, &c.Phone, &c.Address); err != nil {
+//		return err
+//	}
+func AtLeastN(name string, n int, fields ...RequiredIface) error {
+	present := 0
+	for _, f := range fields {
+		if f.HasValue() {
+			present++
+		}
+	}
+	if present < n {
+		return fmt.Errorf("%w: %s: at least %d of %d are required, %d present", ErrTooFewPresent, name, n, len(fields), present)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type ContactMethods struct {
+	Email Required[string] `json:"email"`
+	Phone Required[string] `json:"phone"`
+	Fax   Required[string] `json:"fax"`
+}
+
+func (c *ContactMethods) validate() error {
+	err := New(WithSkipFields("Email", "Phone", "Fax")).Struct(c)
+	return errors.Join(err, AtLeastN("contact methods", 2, &c.Email, &c.Phone, &c.Fax))
+}
+
+func TestAtLeastN(t *testing.T) {
+	req := require.New(t)
+
+	var c ContactMethods
+	c.Email.Set("saoirse@example.com")
+	err := c.validate()
+	req.ErrorIs(err, ErrTooFewPresent)
+	req.Equal("too few fields present: contact methods: at least 2 of 3 are required, 1 present", err.Error())
+
+	c.Phone.Set("555-0100")
+	req.NoError(c.validate())
+
+	c.Fax.Set("555-0101")
+	req.NoError(c.validate())
+
+	req.NoError(AtLeastN("none", 0))
+	req.ErrorIs(AtLeastN("none", 1), ErrTooFewPresent)
+}
@@ -0,0 +1,96 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+)
+
+// DefaultMaxBodySize is the maximum size of request bodies accepted by [Bind] unless configured otherwise.
+const DefaultMaxBodySize = 1 << 20
+
+var (
+	// ErrUnsupportedMediaType indicates that the content type of a request is not supported.
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	// ErrBodyTooLarge indicates that a request body exceeds the maximum size.
+	ErrBodyTooLarge = errors.New("request body too large")
+)
+
+// Bind decodes the body of the request into the provided struct instance according to its content type
+// and validates it. Besides the content types registered with [RegisterContentDecoder], such as JSON,
+// URL-encoded forms are supported and decoded like by [ParseValues].
+// A request without a content type is assumed to carry JSON.
+// Bodies larger than [DefaultMaxBodySize] are rejected with [ErrBodyTooLarge].
+func Bind(r *http.Request, obj interface{}) error {
+	return defaultValidator.Bind(r, obj)
+}
+
+// Bind is like [Bind] but validates the object with the validator,
+// limiting the body size as configured by [WithMaxBodySize].
+func (vd *Validator) Bind(r *http.Request, obj interface{}) error {
+	ct := r.Header.Get("Content-Type")
+	if ct == "" {
+		ct = "application/json"
+	}
+	mt, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUnsupportedMediaType, err)
+	}
+	form := mt == "application/x-www-form-urlencoded"
+	if _, ok := lookupContentDecoder(mt); !ok && !form {
+		return fmt.Errorf("%w: %s", ErrUnsupportedMediaType, mt)
+	}
+	limit := vd.maxBodySize
+	if limit == 0 {
+		limit = DefaultMaxBodySize
+	}
+	r.Body = http.MaxBytesReader(nil, r.Body, limit)
+	if form {
+		if err = r.ParseForm(); err == nil {
+			err = vd.ParseValues(r.PostForm, obj)
+		}
+	} else {
+		err = vd.ParseWith(mt, r.Body, obj)
+	}
+	if mberr := (*http.MaxBytesError)(nil); errors.As(err, &mberr) {
+		return fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, mberr.Limit)
+	}
+	return err
+}
+
+type fieldErrorBody struct {
+	Field   string `json:"field"`
+	Pointer string `json:"pointer"`
+	Message string `json:"message"`
+}
+
+type errorBody struct {
+	Error  string           `json:"error"`
+	Fields []fieldErrorBody `json:"fields,omitempty"`
+}
+
+// WriteValidationError writes an HTTP response describing the error.
+// If the error is a [ValidationError], the response has the status 400 and its JSON body lists the misbehaving fields.
+// Otherwise the status is 500 and the error is not disclosed.
+func WriteValidationError(w http.ResponseWriter, err error) {
+	var (
+		verr   *ValidationError
+		status int
+		body   errorBody
+	)
+	if errors.As(err, &verr) {
+		status = http.StatusBadRequest
+		body.Error = http.StatusText(status)
+		for _, f := range verr.Fields {
+			body.Fields = append(body.Fields, fieldErrorBody{Field: f.Field, Pointer: f.Pointer, Message: f.Error()})
+		}
+	} else {
+		status = http.StatusInternalServerError
+		body.Error = http.StatusText(status)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,66 @@
+package validate
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteValidationError(t *testing.T) {
+	req := require.New(t)
+
+	var p Person
+	err := Parse(strings.NewReader(`{}`), &p)
+	req.Error(err)
+
+	rec := httptest.NewRecorder()
+	WriteValidationError(rec, err)
+	req.Equal(http.StatusBadRequest, rec.Code)
+	req.Equal("application/json", rec.Header().Get("Content-Type"))
+	req.JSONEq(`{
+		"error": "Bad Request",
+		"fields": [
+			{"field": "Name", "pointer": "/name", "message": "field 'Name' in 'validate.Person' is required"},
+			{"field": "Age", "pointer": "/age", "message": "field 'Age' in 'validate.Person' is required"}
+		]
+	}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	WriteValidationError(rec, errors.New("database is down"))
+	req.Equal(http.StatusInternalServerError, rec.Code)
+	req.JSONEq(`{"error": "Internal Server Error"}`, rec.Body.String())
+}
+
+func TestBind(t *testing.T) {
+	req := require.New(t)
+
+	r := httptest.NewRequest(http.MethodPost, "/people", strings.NewReader(`{"name":"Saoirse","age":25}`))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+	var p Person
+	req.NoError(Bind(r, &p))
+	req.Equal("Saoirse", p.Name.Value())
+
+	r = httptest.NewRequest(http.MethodPost, "/people", strings.NewReader(`{"name":"Saoirse"}`))
+	p = Person{}
+	err := Bind(r, &p)
+	req.Equal("field 'Age' in 'validate.Person' is required", err.Error())
+
+	r = httptest.NewRequest(http.MethodPost, "/people", strings.NewReader(`<person/>`))
+	r.Header.Set("Content-Type", "application/xml")
+	err = Bind(r, &p)
+	req.ErrorIs(err, ErrUnsupportedMediaType)
+
+	body := `{"name":"` + strings.Repeat("a", 100) + `","age":25}`
+	r = httptest.NewRequest(http.MethodPost, "/people", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	err = New(WithMaxBodySize(64)).Bind(r, &p)
+	req.ErrorIs(err, ErrBodyTooLarge)
+
+	r = httptest.NewRequest(http.MethodPost, "/people", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	req.NoError(New(WithMaxBodySize(512)).Bind(r, &p))
+}
@@ -0,0 +1,64 @@
+package validate
+
+import (
+	"errors"
+	"io"
+)
+
+// ParseJSONC is like [Parse] but accepts JSON with `//` line comments and `/* */` block comments,
+// e.g. human-edited fixtures. The comments are replaced with whitespace before decoding,
+// so offsets in syntax errors still point into the original input. Comment-like sequences
+// inside strings are kept intact.
+func ParseJSONC(r io.Reader, obj interface{}) error {
+	return defaultValidator.ParseJSONC(r, obj)
+}
+
+// ParseJSONC is like [ParseJSONC] but validates the object with the validator.
+func (vd *Validator) ParseJSONC(r io.Reader, obj interface{}) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if err := stripComments(b); err != nil {
+		return err
+	}
+	return vd.parseBytes(b, obj)
+}
+
+var errUnterminatedComment = errors.New("jsonc: unterminated block comment")
+
+// stripComments replaces the comments in the JSON expression b with spaces in place.
+// Line breaks inside block comments are kept.
+func stripComments(b []byte) error {
+	for i := 0; i < len(b); i++ {
+		switch {
+		case b[i] == '"':
+			// skip the string
+			for i++; i < len(b) && b[i] != '"'; i++ {
+				if b[i] == '\\' {
+					i++
+				}
+			}
+		case b[i] == '/' && i+1 < len(b) && b[i+1] == '/':
+			for ; i < len(b) && b[i] != '\n'; i++ {
+				b[i] = ' '
+			}
+		case b[i] == '/' && i+1 < len(b) && b[i+1] == '*':
+			b[i], b[i+1] = ' ', ' '
+			for i += 2; ; i++ {
+				if i+1 >= len(b) {
+					return errUnterminatedComment
+				}
+				if b[i] == '*' && b[i+1] == '/' {
+					b[i], b[i+1] = ' ', ' '
+					i++
+					break
+				}
+				if b[i] != '\n' {
+					b[i] = ' '
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJSONC(t *testing.T) {
+	req := require.New(t)
+
+	var p Person
+	err := ParseJSONC(strings.NewReader(`{
+		// the full name
+		"name": "Saoirse", /* the age
+		   in years */ "age": 25 // trailing
+	}`), &p)
+	req.NoError(err)
+	req.Equal("Saoirse", p.Name.Get())
+	req.Equal(25, p.Age.Get())
+
+	// comment-like sequences in strings are kept
+	p = Person{}
+	err = ParseJSONC(strings.NewReader(`{"name": "http://example.com/* not a comment */ \"// nor this\"", "age": 1}`), &p)
+	req.NoError(err)
+	req.Equal(`http://example.com/* not a comment */ "// nor this"`, p.Name.Get())
+
+	// the result is validated
+	p = Person{}
+	err = ParseJSONC(strings.NewReader(`{"name": "Saoirse" /* "age": 25 */}`), &p)
+	req.EqualError(err, "field 'Age' in 'validate.Person' is required")
+
+	p = Person{}
+	err = ParseJSONC(strings.NewReader(`{"name": "Saoirse"} /* unterminated`), &p)
+	req.EqualError(err, "jsonc: unterminated block comment")
+}
+
+func TestStripComments(t *testing.T) {
+	req := require.New(t)
+
+	b := []byte("[1, // one\n/* two\n*/ 3]")
+	req.NoError(stripComments(b))
+	req.Equal("[1,       \n      \n   3]", string(b))
+}
@@ -0,0 +1,64 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrTooManyKeys indicates that a JSON object has more keys than allowed by [WithMaxKeys].
+var ErrTooManyKeys = errors.New("too many JSON object keys")
+
+// WithMaxKeys makes [Validator.Parse] and [Validator.ParseBytes] reject JSON expressions whose top-level object,
+// or any object if nested is set, has more than n keys with an error wrapping [ErrTooManyKeys].
+// The keys are counted before decoding, so that excessive payloads never reach the decoder.
+func WithMaxKeys(n int, nested bool) Option {
+	return func(v *Validator) {
+		v.maxKeys = n
+		v.maxKeysNested = nested
+	}
+}
+
+// checkKeys checks that the top-level object of the JSON expression b, or all its objects if nested is set,
+// have at most limit keys. Malformed expressions are left to be reported by the decoder.
+func checkKeys(b []byte, limit int, nested bool) error {
+	type frame struct {
+		object bool
+		// key is set if the next token of the object is a key
+		key  bool
+		keys int
+	}
+	var stack []frame
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil
+		}
+		if n := len(stack); n > 0 && stack[n-1].key && tok != json.Delim('}') {
+			top := &stack[n-1]
+			top.key = false
+			top.keys++
+			if top.keys > limit && (nested || n == 1) {
+				return fmt.Errorf("%w: exceeds %d keys at offset %d", ErrTooManyKeys, limit, dec.InputOffset())
+			}
+			continue
+		}
+		switch tok {
+		case json.Delim('{'):
+			stack = append(stack, frame{object: true, key: true})
+			continue
+		case json.Delim('['):
+			stack = append(stack, frame{})
+			continue
+		case json.Delim('}'), json.Delim(']'):
+			stack = stack[:len(stack)-1]
+		}
+		// a value has been completed
+		if n := len(stack); n > 0 && stack[n-1].object {
+			stack[n-1].key = true
+		}
+	}
+}
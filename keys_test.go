@@ -0,0 +1,44 @@
+package validate
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMaxKeys(t *testing.T) {
+	req := require.New(t)
+
+	vd := New(WithMaxKeys(3, false))
+
+	var tr Tree
+	req.NoError(vd.Parse(strings.NewReader(`{"name":"a","children":[{"name":"b","x":1,"y":2,"z":{"a":1}}]}`), &tr))
+	req.Equal("a", tr.Name.Get())
+
+	var sb strings.Builder
+	sb.WriteString(`{"name":"a"`)
+	for i := 0; i < 1000; i++ {
+		sb.WriteString(`,"k` + strconv.Itoa(i) + `":0`)
+	}
+	sb.WriteString(`}`)
+	tr = Tree{}
+	err := vd.Parse(strings.NewReader(sb.String()), &tr)
+	req.ErrorIs(err, ErrTooManyKeys)
+	req.Equal("too many JSON object keys: exceeds 3 keys at offset 30", err.Error())
+	req.False(tr.Name.HasValue())
+
+	// nested objects are only limited if requested
+	nested := `{"name":"a","children":[{"name":"b","x":1,"y":2,"z":3}]}`
+	tr = Tree{}
+	req.NoError(vd.ParseBytes([]byte(nested), &tr))
+	tr = Tree{}
+	err = New(WithMaxKeys(3, true)).ParseBytes([]byte(nested), &tr)
+	req.ErrorIs(err, ErrTooManyKeys)
+
+	// keys in strings and arrays are not counted
+	tr = Tree{}
+	req.NoError(New(WithMaxKeys(1, true)).ParseBytes([]byte(`{"name":"{\"a\":1,\"b\":2}"}`), &tr))
+	req.NoError(checkKeys([]byte(`[{"a":1},{"b":2},{"c":[1,2,3]}]`), 1, true))
+}
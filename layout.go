@@ -0,0 +1,134 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// requiredField describes a field of type [Required] of a structure.
+type requiredField struct {
+	name  string
+	index []int
+}
+
+// layout holds the description of a structure type computed by [cachedLayout].
+type layout[T any] struct {
+	once  sync.Once
+	value T
+}
+
+// cachedLayout returns the description of the structure type t held by the cache,
+// computing it with compute on the first access. Concurrent first accesses wait for a single computation.
+func cachedLayout[T any](cache *sync.Map, t reflect.Type, compute func(reflect.Type) T) T {
+	l, ok := cache.Load(t)
+	if !ok {
+		l, _ = cache.LoadOrStore(t, new(layout[T]))
+	}
+	entry := l.(*layout[T])
+	entry.once.Do(func() { entry.value = compute(t) })
+	return entry.value
+}
+
+// requiredLayouts caches the required fields of structure types.
+var requiredLayouts sync.Map // map[reflect.Type]*layout[[]requiredField]
+
+// requiredFields returns the exported fields of the structure type t which implement [RequiredIface],
+// or point to such types, along with their JSON names. Fields tagged `json:"-"` are omitted.
+func requiredFields(t reflect.Type) []requiredField {
+	return cachedLayout(&requiredLayouts, t, func(t reflect.Type) []requiredField {
+		var fields []requiredField
+		for _, f := range reflect.VisibleFields(t) {
+			if !f.IsExported() || !isRequiredField(f.Type) {
+				continue
+			}
+			if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name == "-" {
+				continue
+			}
+			fields = append(fields, requiredField{name: jsonName(f), index: f.Index})
+		}
+		return fields
+	})
+}
+
+// fieldKind tells how the walker treats a field.
+type fieldKind int
+
+const (
+	// plainField is a field of an ordinary type
+	plainField fieldKind = iota
+	// forbiddenField is a field of type [Forbidden], or a pointer to one
+	forbiddenField
+	// presenceField is a field whose presence is tracked, or a pointer to one
+	presenceField
+	// promotedField is unexported or an embedded structure whose fields are visited on their own
+	promotedField
+)
+
+// walkedField describes a field of a structure as visited by the walker.
+type walkedField struct {
+	reflect.StructField
+	kind fieldKind
+	// token is the JSON pointer token of the field
+	token    string
+	rules    bool
+	descends bool
+	// deprecated is set for a field of type [Deprecated], or a pointer to one, which is never mandatory
+	deprecated bool
+}
+
+// walkedLayouts caches the fields of structure types as visited by the walker.
+var walkedLayouts sync.Map // map[reflect.Type]*layout[[]walkedField]
+
+// walkedFields returns the visible fields of the structure type t along with how the walker treats them.
+// The fields are computed once per type.
+func walkedFields(t reflect.Type) []walkedField {
+	return cachedLayout(&walkedLayouts, t, func(t reflect.Type) []walkedField {
+		visible := reflect.VisibleFields(t)
+		fields := make([]walkedField, len(visible))
+		for i, f := range visible {
+			kind := plainField
+			switch {
+			case isForbiddenType(f.Type):
+				kind = forbiddenField
+			case isPresenceType(f.Type) || f.Type.Kind() == reflect.Pointer && isPresenceType(f.Type.Elem()):
+				kind = presenceField
+			case !f.IsExported() || f.Anonymous && indirectType(f.Type).Kind() == reflect.Struct:
+				kind = promotedField
+			}
+			fields[i] = walkedField{
+				StructField: f,
+				kind:        kind,
+				token:       pointerToken(jsonName(f)),
+				rules:       hasRules(f.Tag),
+				descends:    descends(f.Type),
+				deprecated:  reflect.PointerTo(indirectType(f.Type)).Implements(deprecatedIfaceType),
+			}
+		}
+		return fields
+	})
+}
+
+// Fields returns the fields of type [Required] of the structure x points to, keyed by the JSON names of the fields,
+// so that external drivers such as copiers can inspect and set the values directly.
+// Fields tagged `json:"-"` are omitted, and so are nil pointers and fields promoted through nil embedded pointers.
+// The layout of the fields is computed once per type.
+// Fields returns nil if x is not a pointer to a structure.
+func Fields(x interface{}) map[string]RequiredIface {
+	v, err := structPointer(x)
+	if err != nil {
+		return nil
+	}
+	layout := requiredFields(v.Type())
+	m := make(map[string]RequiredIface, len(layout))
+	for _, f := range layout {
+		fv, err := v.FieldByIndexErr(f.index)
+		if err != nil {
+			continue
+		}
+		if x, ok := requiredAt(fv, false); ok {
+			m[f.name] = x
+		}
+	}
+	return m
+}
@@ -0,0 +1,97 @@
+package validate
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFields(t *testing.T) {
+	req := require.New(t)
+
+	var p Person
+	fields := Fields(&p)
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	req.ElementsMatch([]string{"name", "age"}, keys)
+
+	name := fields["name"]
+	name.SettableValue().Set(reflect.ValueOf("Saoirse"))
+	name.SetValid(true)
+	req.Equal("Saoirse", p.Name.Get())
+	req.True(p.Name.HasValue())
+
+	fields["age"].SettableValue().SetInt(25)
+	fields["age"].SetValid(true)
+	req.NoError(Struct(&p))
+	req.Equal(25, p.Age.Get())
+
+	// the layout is cached per type
+	_, ok := requiredLayouts.Load(reflect.TypeFor[Person]())
+	req.True(ok)
+	req.Len(Fields(&Person{}), 2)
+
+	req.Nil(Fields(p))
+	req.Nil(Fields((*Person)(nil)))
+}
+
+func TestWalkedFieldsConcurrent(t *testing.T) {
+	req := require.New(t)
+
+	// a type never validated before
+	type Hammered struct {
+		Name    Required[string] `json:"name"`
+		Age     Required[int]    `json:"age"`
+		Address Address          `json:"address"`
+		Note    string           `json:"note" max:"3"`
+	}
+	typ := reflect.TypeFor[Hammered]()
+	walkedLayouts.Delete(typ)
+
+	const n = 64
+	var (
+		start   = make(chan struct{})
+		wg      sync.WaitGroup
+		errs    [n]string
+		layouts [n]uintptr
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			h := Hammered{Name: NewRequired("Saoirse"), Note: "long"}
+			errs[i] = Struct(&h).Error()
+			layouts[i] = reflect.ValueOf(walkedFields(typ)).Pointer()
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	for i := 0; i < n; i++ {
+		req.Equal("field 'Age' in 'validate.Hammered' is required\n"+
+			"field 'Address.Street' in 'validate.Hammered' is required\n"+
+			"field 'Address.Zip' in 'validate.Hammered' is required\n"+
+			"field 'Note' in 'validate.Hammered' is invalid: must have a length of at most 3", errs[i])
+		// all the goroutines see the single computed layout
+		req.Equal(layouts[0], layouts[i])
+	}
+}
+
+func TestFieldsPointer(t *testing.T) {
+	req := require.New(t)
+
+	var p Patch
+	fields := Fields(&p)
+	req.Len(fields, 1)
+	req.Contains(fields, "note")
+
+	p.Count = &Required[int]{}
+	fields = Fields(&p)
+	req.Len(fields, 2)
+	req.Same(p.Count, fields["count"])
+}
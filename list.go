@@ -0,0 +1,22 @@
+package validate
+
+import "bytes"
+
+// RequiredList is a [Required] slice which accepts a single value as well as an array in JSON,
+// e.g. both `"x"` and `["x","y"]`. A single value is decoded as a slice with one element.
+type RequiredList[T any] struct {
+	Required[[]T]
+}
+
+var _ RequiredIface = (*RequiredList[int])(nil)
+
+// UnmarshalJSON unmarshals an array, or a single value as a one-element slice, and marks the instance as valid.
+func (r *RequiredList[T]) UnmarshalJSON(b []byte) error {
+	if t := bytes.TrimSpace(b); len(t) > 0 && t[0] != '[' && !bytes.Equal(t, []byte("null")) {
+		b = append(append([]byte{'['}, t...), ']')
+	}
+	return r.Required.UnmarshalJSON(b)
+}
+
+// List returns the normalized slice, which is nil if there is no value.
+func (r *RequiredList[T]) List() []T { return r.value }
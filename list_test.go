@@ -0,0 +1,46 @@
+package validate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Tagged struct {
+	Tags RequiredList[string] `json:"tags"`
+	IDs  RequiredList[int]    `json:"ids"`
+}
+
+func TestRequiredList(t *testing.T) {
+	req := require.New(t)
+
+	var tg Tagged
+	req.NoError(json.Unmarshal([]byte(`{"tags":"x","ids":[1,2]}`), &tg))
+	req.NoError(Struct(&tg))
+	req.Equal([]string{"x"}, tg.Tags.List())
+	req.Equal([]int{1, 2}, tg.IDs.List())
+
+	tg = Tagged{}
+	req.NoError(json.Unmarshal([]byte(`{"tags":["x","y"],"ids":7}`), &tg))
+	req.Equal([]string{"x", "y"}, tg.Tags.List())
+	req.Equal([]int{7}, tg.IDs.List())
+
+	tg = Tagged{}
+	req.NoError(json.Unmarshal([]byte(`{"tags":[],"ids":[]}`), &tg))
+	req.NoError(Struct(&tg))
+	req.Equal([]string{}, tg.Tags.List())
+	err := New(WithZeroAsMissing()).Struct(&tg)
+	req.NoError(err)
+
+	tg = Tagged{}
+	req.NoError(json.Unmarshal([]byte(`{"ids":1}`), &tg))
+	req.False(tg.Tags.HasValue())
+	req.Nil(tg.Tags.List())
+	err = Struct(&tg)
+	req.Equal("field 'Tags' in 'validate.Tagged' is required", err.Error())
+
+	tg = Tagged{}
+	req.Error(json.Unmarshal([]byte(`{"tags":1}`), &tg))
+	req.False(tg.Tags.HasValue())
+}
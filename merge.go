@@ -0,0 +1,68 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Merge copies the fields of type [Required] which have a value from src into dst,
+// leaving the other fields of dst intact. The sources of the copied values are preserved.
+// Merging into a frozen field fails with [ErrFrozen].
+// Both arguments must be pointers to structures of the same type.
+func Merge(dst, src interface{}) error {
+	dv, err := structPointer(dst)
+	if err != nil {
+		return err
+	}
+	sv, err := structPointer(src)
+	if err != nil {
+		return err
+	}
+	if dv.Type() != sv.Type() {
+		return fmt.Errorf("%w: cannot merge %T into %T", ErrBadType, src, dst)
+	}
+	for _, f := range reflect.VisibleFields(sv.Type()) {
+		if !f.IsExported() || !isRequiredField(f.Type) {
+			continue
+		}
+		s, err := sv.FieldByIndexErr(f.Index)
+		if err != nil {
+			continue
+		}
+		sx, ok := requiredAt(s, false)
+		if !ok || !sx.HasValue() {
+			continue
+		}
+		d, err := fieldByIndexAlloc(dv, f.Index)
+		if err != nil {
+			return fmt.Errorf("%w: field '%s' in '%s'", err, f.Name, dv.Type())
+		}
+		dx, _ := requiredAt(d, true)
+		if err := writable(dx); err != nil {
+			return fmt.Errorf("field '%s' in '%s' %w", f.Name, dv.Type(), err)
+		}
+		dx.SettableValue().Set(sx.SettableValue())
+		dx.SetValid(true)
+		if sx, ok := sx.(sourced); ok && sx.Source() != SourceNone {
+			dx.(sourced).setSource(sx.Source())
+		}
+	}
+	return nil
+}
+
+// fieldByIndexAlloc returns the nested field of v, allocating any nil embedded pointers on the way.
+func fieldByIndexAlloc(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("%w: nil embedded pointer to unexported type %s", ErrBadType, v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
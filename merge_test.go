@@ -0,0 +1,78 @@
+package validate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Upload struct {
+	TenantID  Required[string] `json:"tenantId"`
+	RequestID Required[string] `json:"requestId"`
+	Name      Required[string] `json:"name"`
+	Size      Required[int]    `json:"size"`
+}
+
+func TestMerge(t *testing.T) {
+	req := require.New(t)
+
+	var body Upload
+	err := json.Unmarshal([]byte(`{"name":"report.pdf","size":1024,"requestId":"from-body"}`), &body)
+	req.NoError(err)
+	req.Error(Struct(&body))
+
+	var headers Upload
+	headers.TenantID.Set("acme")
+	headers.RequestID.Set("from-headers")
+
+	err = Merge(&body, &headers)
+	req.NoError(err)
+	req.NoError(Struct(&body))
+	req.Equal("acme", body.TenantID.Value())
+	req.Equal("from-headers", body.RequestID.Value())
+	req.Equal("report.pdf", body.Name.Value())
+	req.Equal(1024, body.Size.Value())
+	req.Equal(SourceManual, body.TenantID.Source())
+	req.Equal(SourceJSON, body.Name.Source())
+}
+
+func TestMergeEmbedded(t *testing.T) {
+	req := require.New(t)
+
+	var dst, src Derived
+	src.Base = &Base{ID: Required[int]{value: 7, valid: true}}
+
+	err := Merge(&dst, &src)
+	req.NoError(err)
+	req.NotSame(src.Base, dst.Base)
+	req.Equal(7, dst.ID.Value())
+	req.False(dst.Name.HasValue())
+}
+
+func TestMergeBadType(t *testing.T) {
+	req := require.New(t)
+
+	var (
+		u Upload
+		p Person
+	)
+	req.ErrorIs(Merge(&u, &p), ErrBadType)
+	req.ErrorIs(Merge(u, &u), ErrBadType)
+	req.ErrorIs(Merge(&u, (*Upload)(nil)), ErrBadType)
+}
+
+func TestMergePointers(t *testing.T) {
+	req := require.New(t)
+
+	var src, dst Patch
+	src.Count = &Required[int]{}
+	src.Count.Set(3)
+	req.NoError(Merge(&dst, &src))
+	req.Equal(3, dst.Count.Value())
+	req.NotSame(src.Count, dst.Count)
+
+	src = Patch{}
+	req.NoError(Merge(&dst, &src))
+	req.Equal(3, dst.Count.Value())
+}
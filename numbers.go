@@ -0,0 +1,87 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+)
+
+// WithUseNumber makes [Validator.Parse] decode JSON numbers held in interface values as [json.Number]
+// rather than float64 so that large integers keep their precision. Besides plain fields, it applies
+// to the fields of type [Required] whose underlying types hold interface values, e.g. Required[interface{}]
+// or Required[map[string]interface{}], including those of nested structures.
+func WithUseNumber() Option {
+	return func(v *Validator) {
+		v.useNumber = true
+	}
+}
+
+// unmarshal decodes the JSON expression b into obj like [json.Unmarshal], honouring [WithUseNumber].
+func (vd *Validator) unmarshal(b []byte, obj interface{}) error {
+	if !vd.useNumber {
+		return json.Unmarshal(b, obj)
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(obj); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("json: invalid data after top-level value")
+	}
+	return nil
+}
+
+// numbers decodes anew the members of the JSON object b corresponding to the fields of the structure v
+// holding interface values, directly or as the underlying values of [Required] fields, keeping the numbers
+// as [json.Number], and descends into nested objects. [Required.UnmarshalJSON] cannot do so by itself
+// since it knows nothing of the options.
+func numbers(v reflect.Value, b []byte) error {
+	fields := jsonFields(v.Type())
+	return objectMembers(b, func(key string, value json.RawMessage) error {
+		f, ok := matchField(fields, key)
+		if !ok {
+			return nil
+		}
+		fv, err := fieldByIndexAlloc(v, f.index)
+		if err != nil {
+			return err
+		}
+		if x, ok := requiredAt(fv, false); ok {
+			if !x.HasValue() {
+				return nil
+			}
+			fv = x.SettableValue()
+		}
+		if holdsInterfaces(fv.Type()) {
+			dec := json.NewDecoder(bytes.NewReader(value))
+			dec.UseNumber()
+			nv := reflect.New(fv.Type())
+			if err := dec.Decode(nv.Interface()); err != nil {
+				return err
+			}
+			fv.Set(nv.Elem())
+			return nil
+		}
+		for fv.Kind() == reflect.Pointer && !fv.IsNil() {
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Struct && bytes.HasPrefix(bytes.TrimSpace(value), []byte("{")) {
+			return numbers(fv, value)
+		}
+		return nil
+	})
+}
+
+// holdsInterfaces tells whether values of type t may hold interface values other than through structures.
+func holdsInterfaces(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return holdsInterfaces(t.Elem())
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// RequiredOneOf is a required field accepting either of two shapes of JSON values.
+// The unmarshaller tries the type A first and the type B second, and records which of them has matched.
+// The accessors of [RequiredIface] refer to the matched variant, or to A if none has.
+type RequiredOneOf[A, B any] struct {
+	first   Required[A]
+	second  Required[B]
+	variant int
+}
+
+var (
+	_ RequiredIface = (*RequiredOneOf[int, string])(nil)
+	_ ZeroChecker   = (*RequiredOneOf[int, string])(nil)
+)
+
+// UnmarshalJSON unmarshals the value into the first matching variant.
+// A frozen instance is left intact and [ErrFrozen] is returned.
+func (r *RequiredOneOf[A, B]) UnmarshalJSON(b []byte) error {
+	if r.isFrozen() {
+		return ErrFrozen
+	}
+	var first Required[A]
+	errFirst := first.UnmarshalJSON(b)
+	if errFirst == nil {
+		*r = RequiredOneOf[A, B]{first: first, variant: 1}
+		return nil
+	}
+	var second Required[B]
+	errSecond := second.UnmarshalJSON(b)
+	if errSecond == nil {
+		*r = RequiredOneOf[A, B]{second: second, variant: 2}
+		return nil
+	}
+	return fmt.Errorf("value matches neither %s nor %s: %w; %w", reflect.TypeFor[A](), reflect.TypeFor[B](), errFirst, errSecond)
+}
+
+// Variant returns 1 if the first variant has matched, 2 if the second one has, and 0 if there is no value.
+func (r *RequiredOneOf[A, B]) Variant() int { return r.variant }
+
+// First returns the value of the first variant and whether it has matched.
+func (r *RequiredOneOf[A, B]) First() (A, bool) { return r.first.value, r.variant == 1 }
+
+// Second returns the value of the second variant and whether it has matched.
+func (r *RequiredOneOf[A, B]) Second() (B, bool) { return r.second.value, r.variant == 2 }
+
+func (r *RequiredOneOf[A, B]) current() RequiredIface {
+	if r.variant == 2 {
+		return &r.second
+	}
+	return &r.first
+}
+
+func (r *RequiredOneOf[A, B]) freeze() {
+	r.first.freeze()
+	r.second.freeze()
+}
+
+func (r *RequiredOneOf[A, B]) isFrozen() bool { return r.first.frozen }
+
+func (r *RequiredOneOf[A, B]) String() string {
+	if r.variant == 2 {
+		return r.second.String()
+	}
+	return r.first.String()
+}
+
+// Clear resets both variants and marks the instance as invalid. It does nothing if the instance is frozen.
+func (r *RequiredOneOf[A, B]) Clear() {
+	if !r.isFrozen() {
+		*r = RequiredOneOf[A, B]{}
+	}
+}
+
+// Touched returns true if the instance has been unmarshalled into.
+func (r *RequiredOneOf[A, B]) Touched() bool { return r.first.touched || r.second.touched }
+
+// HasValue returns true if either variant has matched.
+func (r *RequiredOneOf[A, B]) HasValue() bool { return r.variant != 0 }
+
+// Value returns the value of the matched variant.
+func (r *RequiredOneOf[A, B]) Value() interface{} { return r.current().Value() }
+
+// Ptr returns the pointer to the value of the matched variant.
+func (r *RequiredOneOf[A, B]) Ptr() interface{} { return r.current().Ptr() }
+
+// UnsafePtr returns the unsafe pointer to the value of the matched variant.
+func (r *RequiredOneOf[A, B]) UnsafePtr() unsafe.Pointer { return r.current().UnsafePtr() }
+
+// RequiredType returns the type of the matched variant.
+func (r *RequiredOneOf[A, B]) RequiredType() reflect.Type { return r.current().RequiredType() }
+
+// IsStruct returns true if the type of the matched variant is a structure or a pointer to one.
+func (r *RequiredOneOf[A, B]) IsStruct() bool { return r.current().IsStruct() }
+
+// Kind returns the kind of the type of the matched variant.
+func (r *RequiredOneOf[A, B]) Kind() reflect.Kind { return r.current().Kind() }
+
+// SetValid marks the current variant as valid or the instance as invalid.
+// SetValid does nothing if the instance is frozen.
+func (r *RequiredOneOf[A, B]) SetValid(v bool) {
+	if r.isFrozen() {
+		return
+	}
+	switch {
+	case !v:
+		r.variant = 0
+	case r.variant == 0:
+		r.variant = 1
+	}
+	r.current().SetValid(v)
+}
+
+// SettableValue returns the settable (reflection) value of the matched variant.
+func (r *RequiredOneOf[A, B]) SettableValue() reflect.Value { return r.current().SettableValue() }
+
+// IsZeroValue returns true if the value of the matched variant is the zero value of its type.
+func (r *RequiredOneOf[A, B]) IsZeroValue() bool { return r.current().SettableValue().IsZero() }
+
+// IsPresentZero returns true if a variant has matched and its value is the zero value of its type.
+func (r *RequiredOneOf[A, B]) IsPresentZero() bool { return r.HasValue() && r.IsZeroValue() }
@@ -0,0 +1,60 @@
+package validate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type UserRef struct {
+	ID Required[string] `json:"id"`
+}
+
+type Comment struct {
+	Text Required[string]               `json:"text"`
+	User RequiredOneOf[string, UserRef] `json:"user"`
+}
+
+func TestRequiredOneOf(t *testing.T) {
+	req := require.New(t)
+
+	var c Comment
+	err := json.Unmarshal([]byte(`{"text":"hi","user":"id123"}`), &c)
+	req.NoError(err)
+	req.NoError(Struct(&c))
+	req.Equal(1, c.User.Variant())
+	id, ok := c.User.First()
+	req.True(ok)
+	req.Equal("id123", id)
+	_, ok = c.User.Second()
+	req.False(ok)
+	req.Equal("id123", c.User.Value())
+	req.Equal("id123", c.User.String())
+
+	c = Comment{}
+	err = json.Unmarshal([]byte(`{"text":"hi","user":{"id":"id123"}}`), &c)
+	req.NoError(err)
+	req.NoError(Struct(&c))
+	req.Equal(2, c.User.Variant())
+	ref, ok := c.User.Second()
+	req.True(ok)
+	req.Equal("id123", ref.ID.Value())
+
+	c = Comment{}
+	err = json.Unmarshal([]byte(`{"text":"hi","user":{}}`), &c)
+	req.NoError(err)
+	err = Struct(&c)
+	req.Equal("field 'User.ID' in 'validate.Comment' is required", err.Error())
+
+	c = Comment{}
+	err = json.Unmarshal([]byte(`{"text":"hi","user":42}`), &c)
+	req.ErrorContains(err, "value matches neither string nor validate.UserRef")
+	req.False(c.User.HasValue())
+
+	c = Comment{}
+	err = json.Unmarshal([]byte(`{"text":"hi"}`), &c)
+	req.NoError(err)
+	err = Struct(&c)
+	req.Equal("field 'User' in 'validate.Comment' is required", err.Error())
+}
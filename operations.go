@@ -0,0 +1,42 @@
+package validate
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// StructFor is like [Struct] but validates the structure for the given operation, e.g. `create` or `update`,
+// so that a type can be shared by several endpoints. A field tagged with `required:"create,update"`
+// is only enforced for the listed operations; fields without the tag are enforced for all operations
+// unless the validator has been created with [WithUntaggedOptional]. [Struct] ignores the tag.
+func StructFor(x interface{}, op string) error {
+	return defaultValidator.StructFor(x, op)
+}
+
+// StructFor is like [StructFor] but validates the structure with the validator.
+// [FastValidatable] implementations are not dispatched to.
+func (vd *Validator) StructFor(x interface{}, op string) error {
+	v, err := structPointer(x)
+	if err != nil {
+		return err
+	}
+	w := walker{vd: vd, typ: vd.nameType(v.Type()), op: op}
+	w.structure(v, "", "")
+	return w.err()
+}
+
+// mandatory tells whether the field of type [Required] of the structure v must have a value
+// for the operation being validated, if any.
+func (w *walker) mandatory(v reflect.Value, f reflect.StructField) (bool, error) {
+	if w.op != "" {
+		if ops, ok := f.Tag.Lookup("required"); ok {
+			if !slices.Contains(strings.Split(ops, ","), w.op) {
+				return false, nil
+			}
+		} else if w.vd.untaggedOptional {
+			return false, nil
+		}
+	}
+	return mandatory(v, f)
+}
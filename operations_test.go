@@ -0,0 +1,44 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Article struct {
+	ID    Required[string] `json:"id" required:"update"`
+	Title Required[string] `json:"title" required:"create"`
+	Body  Required[string] `json:"body" required:"create,update"`
+	Tags  Required[[]string]
+}
+
+func TestStructFor(t *testing.T) {
+	req := require.New(t)
+
+	var a Article
+	a.Tags.Set(nil)
+	err := StructFor(&a, "create")
+	req.Equal("field 'Title' in 'validate.Article' is required\nfield 'Body' in 'validate.Article' is required", err.Error())
+	err = StructFor(&a, "update")
+	req.Equal("field 'ID' in 'validate.Article' is required\nfield 'Body' in 'validate.Article' is required", err.Error())
+
+	a.Title.Set("Hello")
+	a.Body.Set("World")
+	req.NoError(StructFor(&a, "create"))
+	req.Error(StructFor(&a, "update"))
+	a.ID.Set("1")
+	req.NoError(StructFor(&a, "update"))
+
+	// untagged fields are enforced for all operations unless configured otherwise
+	a.Tags.Clear()
+	err = StructFor(&a, "update")
+	req.Equal("field 'Tags' in 'validate.Article' is required", err.Error())
+	req.NoError(New(WithUntaggedOptional()).StructFor(&a, "update"))
+
+	// Struct ignores the operations
+	a = Article{}
+	err = Struct(&a)
+	req.Equal("field 'ID' in 'validate.Article' is required\nfield 'Title' in 'validate.Article' is required\n"+
+		"field 'Body' in 'validate.Article' is required\nfield 'Tags' in 'validate.Article' is required", err.Error())
+}
@@ -1,19 +1,232 @@
 package validate
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"reflect"
 )
 
 // Parse parses a JSON expression into the provided struct instance
 // and validates it.
 // Any fields whose type is [Required] are checked that they have a value assigned to them from the incoming JSON.
+// Top-level fields tagged with `jsonalt:"old_name,legacy_name"` are recognised under the alternative names too
+// if they are missing under their primary names.
+// Only the first JSON expression is read from r; whatever follows it is left unread, whichever options are set.
 //
 // Parse returns a multi-error wrapping all the errors that have occurred in the course of the verification.
 func Parse(r io.Reader, obj interface{}) error {
-	if err := json.NewDecoder(r).Decode(obj); err != nil {
+	return defaultValidator.Parse(r, obj)
+}
+
+// ParseInto parses a JSON expression into a new instance of T and validates it like [Parse].
+// It returns nil along with the error if decoding or validation fails.
+func ParseInto[T any](r io.Reader) (*T, error) {
+	obj := new(T)
+	if err := Parse(r, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// Parse parses a JSON expression into the provided struct instance and validates it.
+func (vd *Validator) Parse(r io.Reader, obj interface{}) error {
+	if vd.unknownField == nil && vd.cache == nil && vd.maxDepth == 0 && vd.maxKeys == 0 && !vd.useNumber && !objHasAliases(obj) {
+		if err := json.NewDecoder(r).Decode(obj); err != nil {
+			return err
+		}
+		return vd.Struct(obj)
+	}
+	// like the decoder above, only the first JSON value is read, any data after it is left unread
+	var b json.RawMessage
+	if err := json.NewDecoder(r).Decode(&b); err != nil {
+		return err
+	}
+	return vd.parseBytes(b, obj)
+}
+
+// ParseBytes is like [Parse] but parses the JSON expression from a byte slice.
+func ParseBytes(b []byte, obj interface{}) error {
+	return defaultValidator.ParseBytes(b, obj)
+}
+
+// ParseBytes parses a JSON expression from a byte slice into the provided struct instance and validates it.
+func (vd *Validator) ParseBytes(b []byte, obj interface{}) error {
+	return vd.parseBytes(b, obj)
+}
+
+func (vd *Validator) parseBytes(b []byte, obj interface{}) error {
+	if vd.maxDepth > 0 {
+		if err := checkDepth(b, vd.maxDepth); err != nil {
+			return err
+		}
+	}
+	if vd.maxKeys > 0 {
+		if err := checkKeys(b, vd.maxKeys, vd.maxKeysNested); err != nil {
+			return err
+		}
+	}
+	if err := vd.unmarshal(b, obj); err != nil {
+		return err
+	}
+	v, err := structPointer(obj)
+	if err != nil {
+		return err
+	}
+	if vd.useNumber {
+		if err := numbers(v, b); err != nil {
+			return err
+		}
+	}
+	if vd.unknownField != nil || hasAliases(v.Type()) {
+		if err := vd.members(v, b); err != nil {
+			return err
+		}
+	}
+	if vd.cache != nil && vd.metrics == nil && vd.nullWarning == nil && vd.deprecation == nil {
+		k := payloadKey(v.Type(), b)
+		if vd.cache.contains(k) {
+			return nil
+		}
+		err := vd.Struct(obj)
+		if err == nil {
+			vd.cache.add(k)
+		}
 		return err
 	}
+	return vd.Struct(obj)
+}
+
+// members processes the members of the top-level JSON object not handled by [json.Unmarshal],
+// that is, the alternative names of fields and the unknown keys.
+func (vd *Validator) members(v reflect.Value, b []byte) error {
+	type member struct {
+		key   string
+		value json.RawMessage
+	}
+	var (
+		fields  = jsonFields(v.Type())
+		present = make(map[string]bool)
+		aliased []member
+	)
+	if err := objectMembers(b, func(key string, value json.RawMessage) error {
+		if f, ok := matchField(fields, key); ok {
+			present[f.name] = true
+			return nil
+		}
+		aliased = append(aliased, member{key, value})
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, m := range aliased {
+		f, ok := matchAlias(fields, m.key)
+		if !ok {
+			if vd.unknownField != nil {
+				vd.unknownField(m.key)
+			}
+			continue
+		}
+		if present[f.name] {
+			// the field has been provided under its primary or an earlier alternative name
+			continue
+		}
+		present[f.name] = true
+		fv, err := fieldByIndexAlloc(v, f.index)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(m.value, fv.Addr().Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func objHasAliases(obj interface{}) bool {
+	t := reflect.TypeOf(obj)
+	return t != nil && t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct && hasAliases(t.Elem())
+}
+
+// ParseNDJSON parses newline-delimited JSON, decoding each line into a fresh object obtained from new
+// and validating it. The callback out is invoked for every non-blank line with the object and the error,
+// if any, that has occurred while decoding or validating it. Parsing continues past bad lines.
+// If reading fails, out is invoked with a nil object and the read error and parsing stops.
+func ParseNDJSON(r io.Reader, new func() interface{}, out func(obj interface{}, err error)) {
+	defaultValidator.ParseNDJSON(r, new, out)
+}
 
-	return Struct(obj)
+// ParseNDJSON is like [ParseNDJSON] but validates the objects with the validator.
+func (vd *Validator) ParseNDJSON(r io.Reader, new func() interface{}, out func(obj interface{}, err error)) {
+	br := bufio.NewReader(r)
+	processed := 0
+	for {
+		line, err := br.ReadBytes('\n')
+		if line := bytes.TrimSpace(line); len(line) > 0 {
+			obj := new()
+			if err := json.Unmarshal(line, obj); err != nil {
+				out(obj, err)
+			} else {
+				out(obj, vd.Struct(obj))
+			}
+			processed++
+			vd.reportProgress(processed)
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				out(nil, err)
+			}
+			return
+		}
+	}
+}
+
+// ParseStream parses a JSON array element by element, decoding each element into a fresh object obtained from new
+// and validating it, without reading the whole array into memory. The callback out is invoked for every element
+// with the object and the error, if any, that has occurred while decoding or validating it.
+// Parsing continues past elements of mismatched types; on a syntax or read error,
+// out is invoked with a nil object and the error and parsing stops.
+func ParseStream(r io.Reader, new func() interface{}, out func(obj interface{}, err error)) {
+	defaultValidator.ParseStream(r, new, out)
+}
+
+// ParseStream is like [ParseStream] but validates the objects with the validator.
+func (vd *Validator) ParseStream(r io.Reader, new func() interface{}, out func(obj interface{}, err error)) {
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil {
+		out(nil, err)
+		return
+	} else if tok != json.Delim('[') {
+		out(nil, fmt.Errorf("%w: expected a JSON array", ErrBadType))
+		return
+	}
+	processed := 0
+	for dec.More() {
+		obj := new()
+		err := dec.Decode(obj)
+		var terr *json.UnmarshalTypeError
+		switch {
+		case err == nil:
+			out(obj, vd.Struct(obj))
+		case errors.As(err, &terr):
+			out(obj, err)
+		default:
+			out(nil, err)
+			return
+		}
+		processed++
+		vd.reportProgress(processed)
+	}
+	if _, err := dec.Token(); err != nil {
+		out(nil, err)
+	}
+}
+
+func (vd *Validator) reportProgress(processed int) {
+	if vd.progress != nil && processed%vd.progressEvery == 0 {
+		vd.progress(processed)
+	}
 }
@@ -0,0 +1,301 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNDJSON(t *testing.T) {
+	req := require.New(t)
+
+	input := `{"name":"Saoirse","age":25}
+{"name":"Aoife"}
+
+{"name":
+{"age":30,"name":"Niamh"}`
+
+	var (
+		names []string
+		errs  []string
+	)
+	ParseNDJSON(strings.NewReader(input), func() interface{} { return new(Person) }, func(obj interface{}, err error) {
+		p := obj.(*Person)
+		names = append(names, p.Name.String())
+		if err != nil {
+			errs = append(errs, err.Error())
+		} else {
+			errs = append(errs, "")
+		}
+	})
+	req.Equal([]string{"Saoirse", "Aoife", "N/A", "Niamh"}, names)
+	req.Equal([]string{
+		"",
+		"field 'Age' in 'validate.Person' is required",
+		"unexpected end of JSON input",
+		"",
+	}, errs)
+}
+
+func TestParseNDJSONReadError(t *testing.T) {
+	req := require.New(t)
+
+	errRead := errors.New("connection reset")
+	r := io.MultiReader(strings.NewReader("{\"name\":\"Saoirse\",\"age\":25}\n"), iotest.ErrReader(errRead))
+
+	var got []error
+	ParseNDJSON(r, func() interface{} { return new(Person) }, func(obj interface{}, err error) {
+		got = append(got, err)
+	})
+	req.Equal([]error{nil, errRead}, got)
+}
+
+func TestWithUnknownFieldCallback(t *testing.T) {
+	req := require.New(t)
+
+	var unknown []string
+	vd := New(WithUnknownFieldCallback(func(name string) { unknown = append(unknown, name) }))
+
+	var p Person
+	err := vd.Parse(strings.NewReader(`{"nickname":"Sao","NAME":"Saoirse","age":25,"legacy_id":7}`), &p)
+	req.NoError(err)
+	req.Equal([]string{"nickname", "legacy_id"}, unknown)
+	req.Equal("Saoirse", p.Name.Value())
+
+	unknown = nil
+	p = Person{}
+	err = vd.Parse(strings.NewReader(`{"name":"Saoirse","extra":true}`), &p)
+	req.Equal("field 'Age' in 'validate.Person' is required", err.Error())
+	req.Equal([]string{"extra"}, unknown)
+
+	unknown = nil
+	err = vd.Parse(strings.NewReader(`{"name":`), &p)
+	req.Error(err)
+	req.Empty(unknown)
+
+	unknown = nil
+	var o Order
+	err = vd.Parse(strings.NewReader(`{"id":"o1","address":{"street":"Main","zip":"1","floor":2},"billing":{"street":"Main","zip":"1"},"coupon":"X"}`), &o)
+	req.NoError(err)
+	req.Equal([]string{"coupon"}, unknown)
+}
+
+type Customer struct {
+	Name  Required[string] `json:"name" jsonalt:"full_name,fullName"`
+	Email Required[string] `json:"email" jsonalt:"mail"`
+	Tier  string           `json:"tier" jsonalt:"level"`
+}
+
+func TestParseAlternativeNames(t *testing.T) {
+	req := require.New(t)
+
+	for _, payload := range []string{
+		`{"name":"Saoirse","email":"s@example.com"}`,
+		`{"full_name":"Saoirse","mail":"s@example.com"}`,
+		`{"fullName":"Saoirse","MAIL":"s@example.com"}`,
+	} {
+		var c Customer
+		err := Parse(strings.NewReader(payload), &c)
+		req.NoError(err, payload)
+		req.Equal("Saoirse", c.Name.Value())
+		req.Equal("s@example.com", c.Email.Value())
+	}
+
+	// the primary name takes precedence
+	var c Customer
+	err := Parse(strings.NewReader(`{"full_name":"Old","name":"New","email":"s@example.com","level":"gold","tier":"silver"}`), &c)
+	req.NoError(err)
+	req.Equal("New", c.Name.Value())
+	req.Equal("silver", c.Tier)
+
+	c = Customer{}
+	err = Parse(strings.NewReader(`{"fullName":"Saoirse"}`), &c)
+	req.Equal("field 'Email' in 'validate.Customer' is required", err.Error())
+
+	var unknown []string
+	c = Customer{}
+	err = New(WithUnknownFieldCallback(func(name string) { unknown = append(unknown, name) })).
+		Parse(strings.NewReader(`{"full_name":"Saoirse","mail":"s@example.com","phone":"123"}`), &c)
+	req.NoError(err)
+	req.Equal([]string{"phone"}, unknown)
+}
+
+func TestParseStream(t *testing.T) {
+	req := require.New(t)
+
+	input := `[{"name":"Saoirse","age":25}, {"name":"Aoife"}, {"name":"Niamh","age":"old"}, {"age":30,"name":"Orla"}]`
+
+	var (
+		names []string
+		errs  []string
+	)
+	ParseStream(strings.NewReader(input), func() interface{} { return new(Person) }, func(obj interface{}, err error) {
+		names = append(names, obj.(*Person).Name.String())
+		if err != nil {
+			errs = append(errs, err.Error())
+		} else {
+			errs = append(errs, "")
+		}
+	})
+	req.Equal([]string{"Saoirse", "Aoife", "Niamh", "Orla"}, names)
+	req.Equal("", errs[0])
+	req.Equal("field 'Age' in 'validate.Person' is required", errs[1])
+	req.Contains(errs[2], "cannot unmarshal string")
+	req.Equal("", errs[3])
+
+	var got []error
+	ParseStream(strings.NewReader(`{"name":"Saoirse"}`), func() interface{} { return new(Person) }, func(obj interface{}, err error) {
+		req.Nil(obj)
+		got = append(got, err)
+	})
+	req.Len(got, 1)
+	req.ErrorIs(got[0], ErrBadType)
+
+	got = nil
+	ParseStream(strings.NewReader(`[{"name":"Saoirse","age":25}, {"name":`), func() interface{} { return new(Person) }, func(obj interface{}, err error) {
+		got = append(got, err)
+	})
+	req.Len(got, 2)
+	req.NoError(got[0])
+	req.Error(got[1])
+}
+
+func TestWithProgress(t *testing.T) {
+	req := require.New(t)
+
+	var sb strings.Builder
+	sb.WriteString("[")
+	for i := 0; i < 1000; i++ {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString(`{"name":"Saoirse","age":25}`)
+	}
+	sb.WriteString("]")
+
+	var reports []int
+	vd := New(WithProgress(100, func(processed int) { reports = append(reports, processed) }))
+	count := 0
+	vd.ParseStream(strings.NewReader(sb.String()), func() interface{} { return new(Person) }, func(obj interface{}, err error) {
+		req.NoError(err)
+		count++
+	})
+	req.Equal(1000, count)
+	req.Len(reports, 10)
+	req.Equal(100, reports[0])
+	req.Equal(1000, reports[9])
+
+	reports = nil
+	ndjson := strings.Repeat(`{"name":"Saoirse","age":25}`+"\n", 250)
+	vd.ParseNDJSON(strings.NewReader(ndjson), func() interface{} { return new(Person) }, func(obj interface{}, err error) {})
+	req.Equal([]int{100, 200}, reports)
+}
+
+func BenchmarkParseStream(b *testing.B) {
+	payload := "[" + strings.TrimSuffix(strings.Repeat(`{"name":"Saoirse","age":25},`, 1000), ",") + "]"
+	vd := New(WithProgress(100, func(int) {}))
+	for i := 0; i < b.N; i++ {
+		vd.ParseStream(strings.NewReader(payload), func() interface{} { return new(Person) }, func(interface{}, error) {})
+	}
+}
+
+func TestParseInto(t *testing.T) {
+	req := require.New(t)
+
+	p, err := ParseInto[Person](strings.NewReader(`{"name":"Saoirse","age":25}`))
+	req.NoError(err)
+	req.Equal("Saoirse", p.Name.Get())
+	req.Equal(25, p.Age.Get())
+
+	p, err = ParseInto[Person](strings.NewReader(`{"name":"Saoirse"}`))
+	req.Nil(p)
+	req.Equal("field 'Age' in 'validate.Person' is required", err.Error())
+
+	p, err = ParseInto[Person](strings.NewReader(`{"name":`))
+	req.Nil(p)
+	req.ErrorIs(err, io.ErrUnexpectedEOF)
+
+	n, err := ParseInto[int](strings.NewReader(`5`))
+	req.Nil(n)
+	req.ErrorIs(err, ErrBadType)
+}
+
+type Payload struct {
+	ID    Required[interface{}]            `json:"id"`
+	Attrs Required[map[string]interface{}] `json:"attrs"`
+	Raw   interface{}                      `json:"raw"`
+	Count Required[int]                    `json:"count"`
+}
+
+func TestWithUseNumber(t *testing.T) {
+	req := require.New(t)
+
+	const body = `{"id":12345678901234567890,"attrs":{"n":9007199254740993},"raw":9007199254740993,"count":3}`
+
+	var p Payload
+	req.NoError(Parse(strings.NewReader(body), &p))
+	req.IsType(float64(0), p.ID.Get())
+
+	p = Payload{}
+	req.NoError(New(WithUseNumber()).Parse(strings.NewReader(body), &p))
+	req.Equal(json.Number("12345678901234567890"), p.ID.Get())
+	req.Equal(json.Number("9007199254740993"), p.Attrs.Get()["n"])
+	req.Equal(json.Number("9007199254740993"), p.Raw)
+	req.Equal(3, p.Count.Get())
+	req.Equal(SourceJSON, p.ID.Source())
+
+	p = Payload{}
+	err := New(WithUseNumber()).Parse(strings.NewReader(`{"raw":1}`), &p)
+	req.Equal(`field 'ID' in 'validate.Payload' is required
+field 'Attrs' in 'validate.Payload' is required
+field 'Count' in 'validate.Payload' is required`, err.Error())
+
+	err = New(WithUseNumber()).ParseBytes([]byte(`{"id":1} {}`), &p)
+	req.Error(err)
+}
+
+type PayloadBatch struct {
+	In      Payload           `json:"in"`
+	Ptr     *Payload          `json:"ptr"`
+	Wrapped Required[Payload] `json:"wrapped"`
+}
+
+func TestWithUseNumberNested(t *testing.T) {
+	req := require.New(t)
+
+	const inner = `{"id":12345678901234567890,"attrs":{"n":9007199254740993},"raw":9007199254740993,"count":3}`
+	var e PayloadBatch
+	req.NoError(New(WithUseNumber()).Parse(strings.NewReader(`{"in":`+inner+`,"ptr":`+inner+`,"wrapped":`+inner+`}`), &e))
+	for _, p := range []Payload{e.In, *e.Ptr, e.Wrapped.Get()} {
+		req.Equal(json.Number("12345678901234567890"), p.ID.Get())
+		req.Equal(json.Number("9007199254740993"), p.Attrs.Get()["n"])
+		req.Equal(json.Number("9007199254740993"), p.Raw)
+		req.Equal(3, p.Count.Get())
+	}
+}
+
+func TestParseTrailingData(t *testing.T) {
+	req := require.New(t)
+
+	const body = `{"name":"Saoirse","age":25} garbage`
+	for _, vd := range []*Validator{
+		defaultValidator,
+		New(WithMaxDepth(8)),
+		New(WithCache(8)),
+		New(WithUnknownFieldCallback(func(string) {})),
+		New(WithUseNumber()),
+	} {
+		var p Person
+		req.NoError(vd.Parse(strings.NewReader(body), &p))
+		req.Equal("Saoirse", p.Name.Value())
+	}
+
+	var c Customer
+	req.NoError(Parse(strings.NewReader(`{"full_name":"Saoirse","mail":"s@example.com"} garbage`), &c))
+	req.Equal("Saoirse", c.Name.Value())
+}
@@ -0,0 +1,39 @@
+package validate
+
+import "reflect"
+
+// MarkPresentNonZero marks the fields of type [Required] of the structure obj points to which hold non-zero values
+// as valid, including those of nested structures held in fields and values of [Required] fields.
+// It bridges decoders which assign the values without going through [Required.UnmarshalJSON], e.g. mapstructure,
+// so that a subsequent [Struct] sees the fields as present. Zero values are indistinguishable from absent ones
+// and are left as they are. MarkPresentNonZero does nothing if obj is not a pointer to a structure.
+func MarkPresentNonZero(obj interface{}) {
+	if v, err := structPointer(obj); err == nil {
+		markPresent(v)
+	}
+}
+
+// markPresent marks the non-zero fields of the addressable structure v as valid.
+func markPresent(v reflect.Value) {
+	for _, f := range reflect.VisibleFields(v.Type()) {
+		if !f.IsExported() {
+			continue
+		}
+		fv, err := v.FieldByIndexErr(f.Index)
+		if err != nil {
+			continue
+		}
+		x, ok := requiredAt(fv, false)
+		switch {
+		case ok:
+			if !x.HasValue() && !isZero(x) {
+				x.SetValid(true)
+			}
+			if x.Kind() == reflect.Struct {
+				markPresent(x.SettableValue())
+			}
+		case f.Type.Kind() == reflect.Struct && !f.Anonymous && !isForbiddenType(f.Type):
+			markPresent(fv)
+		}
+	}
+}
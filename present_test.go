@@ -0,0 +1,55 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// assignByReflection mimics decoders like mapstructure which set the underlying values directly.
+func assignByReflection(obj interface{}, values map[string]interface{}) {
+	v := reflect.ValueOf(obj).Elem()
+	for name, value := range values {
+		x := v.FieldByName(name).Addr().Interface().(RequiredIface)
+		x.SettableValue().Set(reflect.ValueOf(value))
+	}
+}
+
+func TestMarkPresentNonZero(t *testing.T) {
+	req := require.New(t)
+
+	var c Contact
+	assignByReflection(&c, map[string]interface{}{"Name": "Saoirse", "Email": "saoirse@example.com", "Phone": ""})
+	req.Error(Struct(&c))
+
+	MarkPresentNonZero(&c)
+	req.True(c.Name.HasValue())
+	req.True(c.Email.HasValue())
+	req.Equal(SourceManual, c.Email.Source())
+	req.False(c.Phone.HasValue())
+	err := Struct(&c)
+	req.Equal("field 'Phone' in 'validate.Contact' is required", err.Error())
+
+	var o Order
+	assignByReflection(&o, map[string]interface{}{"ID": "o1"})
+	assignByReflection(&o.Address, map[string]interface{}{"Street": "Main", "Zip": "12345"})
+	assignByReflection(&o, map[string]interface{}{"Billing": Address{Street: Required[string]{value: "High"}, Zip: Required[string]{value: "54321"}}})
+	MarkPresentNonZero(&o)
+	req.NoError(Struct(&o))
+
+	MarkPresentNonZero(o)
+	MarkPresentNonZero(nil)
+}
+
+func TestMarkPresentNonZeroPointer(t *testing.T) {
+	req := require.New(t)
+
+	p := Patch{Count: &Required[int]{value: 5}}
+	MarkPresentNonZero(&p)
+	req.True(p.Count.HasValue())
+
+	p = Patch{}
+	MarkPresentNonZero(&p)
+	req.Nil(p.Count)
+}
@@ -2,10 +2,12 @@
 // that is, it must have a value in the incoming request (which might be `null` for some types but must not be omitted).
 //
 // The type supports JSON unmarshalling provided the underlying type can be unmarshalled from a slice of byte
-// into an instance.
+// into an instance. The underlying value is decoded exactly as [json.Unmarshal] would decode it,
+// so for instance a Required[[]byte] is decoded from a base64 string.
 package validate
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -18,25 +20,142 @@ import (
 // The type supports custom unmarshalling from JSON.
 // Furthermore the keyvalue copier can handle this type provided it figures in the source.
 type Required[T any] struct {
-	value T
-	valid bool
+	value   T
+	valid   bool
+	source  Source
+	frozen  bool
+	touched bool
+	null    bool
 }
 
+// UnmarshalJSON unmarshals the underlying value and marks the instance as valid and touched.
+// A decoder registered with [RegisterDecoder] for the underlying type takes precedence over [json.Unmarshal];
+// if it returns [ErrAbsent], the instance is left without a value. Integer types are decoded by [json.Unmarshal]
+// unless [DecodeIntegral] is registered for them, so numbers such as `25.0` or `1e3` are rejected by default.
+// If T implements [json.Unmarshaler], its UnmarshalJSON receives the raw value, including an explicit `null`,
+// which marks the instance as valid unless T's unmarshaller rejects it; see [Required.WasNull].
+// A frozen instance is left intact and [ErrFrozen] is returned.
 func (r *Required[T]) UnmarshalJSON(b []byte) error {
-	if err := json.Unmarshal(b, &r.value); err != nil {
+	if r.frozen {
+		return ErrFrozen
+	}
+	if decode, ok := lookupDecoder[T](); ok {
+		v, err := decode(b)
+		if errors.Is(err, ErrAbsent) {
+			var zero T
+			r.value, r.valid, r.null = zero, false, false
+			r.touched = true
+			r.source = SourceNone
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		r.value = v
+	} else if err := json.Unmarshal(b, &r.value); err != nil {
 		return err
 	}
 	r.valid = true
+	r.touched = true
+	r.null = bytes.Equal(bytes.TrimSpace(b), []byte("null"))
+	r.source = SourceJSON
 	return nil
 }
 
+// String returns the textual representation of the underlying value, or `N/A` if there is none.
+// Pointers are dereferenced and [fmt.Stringer] implementations are respected.
 func (r *Required[T]) String() string {
 	if r.valid {
-		return fmt.Sprintf("%v", r.value)
+		return display(reflect.ValueOf(&r.value).Elem())
 	}
 	return "N/A"
 }
 
+func display(v reflect.Value) string {
+	for {
+		if v.Kind() == reflect.Pointer && v.IsNil() {
+			return "<nil>"
+		}
+		if v.CanAddr() {
+			// String methods with pointer receivers are respected too
+			if s, ok := v.Addr().Interface().(fmt.Stringer); ok {
+				return s.String()
+			}
+		}
+		if s, ok := v.Interface().(fmt.Stringer); ok {
+			return s.String()
+		}
+		if v.Kind() != reflect.Pointer {
+			return fmt.Sprintf("%v", v.Interface())
+		}
+		v = v.Elem()
+	}
+}
+
+// GoString implements [fmt.GoStringer] for the `%#v` verb, returning a quoted representation of the value
+// along with its type, e.g. `Required[string]("a b")`, or `Required[string](unset)` if there is none.
+func (r *Required[T]) GoString() string {
+	if !r.valid {
+		return fmt.Sprintf("Required[%s](unset)", reflect.TypeFor[T]())
+	}
+	return fmt.Sprintf("Required[%s](%#v)", reflect.TypeFor[T](), r.value)
+}
+
+// Set sets the underlying value and marks the instance as valid.
+// A frozen instance is left intact and [ErrFrozen] is returned.
+func (r *Required[T]) Set(v T) error {
+	if r.frozen {
+		return ErrFrozen
+	}
+	r.value = v
+	r.valid = true
+	r.source = SourceManual
+	return nil
+}
+
+// Clear sets the underlying value to the zero value of T and marks the instance as invalid and untouched.
+// It does nothing if the instance is frozen.
+func (r *Required[T]) Clear() {
+	if !r.frozen {
+		*r = Required[T]{}
+	}
+}
+
+// WasNull returns true if the instance has been unmarshalled from an explicit JSON `null`.
+func (r *Required[T]) WasNull() bool { return r.null }
+
+// State returns the underlying value, whether there is one, and whether it has been unmarshalled
+// from an explicit JSON `null`. It has a value receiver so that it can be called on values which are not addressable.
+func (r Required[T]) State() (value T, present bool, wasNull bool) {
+	return r.value, r.valid, r.null
+}
+
+// SamePresence returns true if both the instance and other have a value or neither has one,
+// regardless of the values themselves. It is useful for detecting presence transitions, e.g. in PATCH requests.
+func (r Required[T]) SamePresence(other Required[T]) bool {
+	return r.valid == other.valid
+}
+
+// Get returns the underlying value, which is the zero value of T if there is none.
+func (r *Required[T]) Get() T { return r.value }
+
+// Apply calls f with a pointer to the underlying value so that it can be modified in place.
+// It does nothing if the instance has no value or is frozen.
+func (r *Required[T]) Apply(f func(*T)) {
+	if r.valid && !r.frozen {
+		f(&r.value)
+	}
+}
+
+// Touched returns true if the instance has been unmarshalled into, that is, if the value has been sent
+// by the client rather than set otherwise, e.g. by [FillDefaults].
+func (r *Required[T]) Touched() bool { return r.touched }
+
+// Source returns the origin of the underlying value.
+func (r *Required[T]) Source() Source { return r.source }
+
+func (r *Required[T]) setSource(s Source) { r.source = s }
+
 // HasValue returns true if the underlying value has been unmarshalled into.
 func (r *Required[T]) HasValue() bool { return r.valid }
 
@@ -54,12 +173,41 @@ func (r *Required[T]) RequiredType() reflect.Type {
 	return reflect.TypeFor[T]()
 }
 
-// SetValid marks the instance as valid, that is, containing a value.
-func (r *Required[T]) SetValid(v bool) { r.valid = true }
+// IsStruct returns true if the underlying type is a structure or a pointer to one,
+// that is, if the underlying value may have fields to be validated.
+func (r *Required[T]) IsStruct() bool {
+	return indirectType(reflect.TypeFor[T]()).Kind() == reflect.Struct
+}
+
+// Kind returns the kind of the underlying value's type.
+func (r *Required[T]) Kind() reflect.Kind { return reflect.TypeFor[T]().Kind() }
+
+// SetValid marks the instance as valid, that is, containing a value, or as invalid.
+// A value which has not come from elsewhere is considered to have been set manually.
+// SetValid does nothing if the instance is frozen.
+func (r *Required[T]) SetValid(v bool) {
+	if r.frozen {
+		return
+	}
+	r.valid = v
+	switch {
+	case !v:
+		r.source = SourceNone
+	case r.source == SourceNone:
+		r.source = SourceManual
+	}
+}
 
 // SettableValue returns the settable (reflection) value of the underlying value.
 func (r *Required[T]) SettableValue() reflect.Value { return reflect.ValueOf(&r.value).Elem() }
 
+// IsZeroValue returns true if the underlying value is the zero value of its type.
+func (r *Required[T]) IsZeroValue() bool { return reflect.ValueOf(&r.value).Elem().IsZero() }
+
+// IsPresentZero returns true if the instance has a value which is the zero value of its type,
+// as opposed to having no value at all.
+func (r *Required[T]) IsPresentZero() bool { return r.valid && r.IsZeroValue() }
+
 // RequiredIface is the interface without type parameters providing access to the [Required] type constructor.
 type RequiredIface interface {
 	HasValue() bool
@@ -67,42 +215,169 @@ type RequiredIface interface {
 	Ptr() interface{}
 	UnsafePtr() unsafe.Pointer
 	RequiredType() reflect.Type
+	Kind() reflect.Kind
+	IsStruct() bool
 	SetValid(bool)
 	SettableValue() reflect.Value
+	IsPresentZero() bool
+	Clear()
+}
+
+// ZeroChecker is implemented by required-like types which can tell whether their value is to be considered zero.
+// Validators created with [WithZeroAsMissing] or [WithEmptyStringAsMissing] consult it.
+type ZeroChecker interface {
+	IsZeroValue() bool
+}
+
+// RequiredContainer is implemented by third-party presence types, such as optional wrappers,
+// which [Struct] treats like [Required]: a field of such a type must be present
+// and its inner value is validated if it is a structure.
+// Inner is only called if Present returns true, so lazy types can materialize their values on demand in Inner;
+// they must synchronize it themselves, e.g. with [sync.Once], if they may be validated concurrently.
+type RequiredContainer interface {
+	Present() bool
+	Inner() interface{}
+}
+
+// FastValidatable is implemented by structures which check their required fields without reflection,
+// typically by generated code. [Struct] dispatches to ValidateRequired when the argument implements it.
+type FastValidatable interface {
+	ValidateRequired() error
 }
 
 var (
 	// RequiredIfaceType is the type of [RequiredIface].
 	RequiredIfaceType = reflect.TypeFor[RequiredIface]()
+
+	requiredContainerType = reflect.TypeFor[RequiredContainer]()
 	// ErrBadType indicates that the provided argument is ill-typed.
 	ErrBadType = errors.New("bad type")
+	// ErrRequired indicates that a required field has no value.
+	ErrRequired = errors.New("is required")
+	// ErrForbidden indicates that a forbidden field has a value.
+	ErrForbidden = errors.New("must not be provided")
+	// ErrInvalid indicates that the value of a field violates a constraint.
+	ErrInvalid = errors.New("is invalid")
+
+	errUnexported = fmt.Errorf("is unexported: %w", ErrBadType)
 
 	_ json.Unmarshaler = (*Required[int])(nil)
 	_ RequiredIface    = (*Required[int])(nil)
+	_ ZeroChecker      = (*Required[int])(nil)
 )
 
+// NewRequired creates an instance holding the provided value.
+func NewRequired[T any](v T) Required[T] {
+	return Required[T]{value: v, valid: true, source: SourceManual}
+}
+
+// NewRequiredPtr creates a pointer to a valid instance holding the provided value.
+func NewRequiredPtr[T any](v T) *Required[T] {
+	r := NewRequired(v)
+	return &r
+}
+
+// NilRequired returns a nil pointer to an instance, which fields of type *Required[T] hold if they are unset.
+func NilRequired[T any]() *Required[T] { return nil }
+
+// Coalesce returns the first instance among its arguments which has a value.
+// If none of them has a value, an instance without a value is returned.
+func Coalesce[T any](rs ...Required[T]) Required[T] {
+	for _, r := range rs {
+		if r.valid {
+			return r
+		}
+	}
+	return Required[T]{}
+}
+
+// Compact returns the underlying values of the instances which have a value, preserving their order.
+func Compact[T any](rs []Required[T]) []T {
+	valid, _ := Partition(rs)
+	return valid
+}
+
+// Partition returns the underlying values of the instances which have a value, preserving their order,
+// along with the number of instances which have none.
+func Partition[T any](rs []Required[T]) (valid []T, missing int) {
+	for _, r := range rs {
+		if r.valid {
+			valid = append(valid, r.value)
+		} else {
+			missing++
+		}
+	}
+	return valid, missing
+}
+
+// Try builds an instance from the result of a fallible conversion.
+// The instance has a value only if err is nil, in which case err is returned unchanged.
+func Try[T any](v T, err error) (Required[T], error) {
+	if err != nil {
+		return Required[T]{}, err
+	}
+	return NewRequired(v), nil
+}
+
 // Struct validates the provided argument which must be a pointer to a structure.
-// Any fields whose type is [Required] are checked.
-// The returned error is a multi-error containing the errors emitted for all misbehaving fields.
+// Any fields whose type is [Required] are checked, including those of nested structures.
+// The returned error is a [ValidationError] containing the errors emitted for all misbehaving fields.
 //
-// Struct panics if the argument is ill-typed.
+// If the argument implements [FastValidatable], its own validation is used instead.
+// A ValidateRequired method promoted from an embedded structure is ignored
+// as it would not check the fields of the embedding structure.
+//
+// Struct never panics; an ill-typed argument yields an error wrapping [ErrBadType].
 func Struct(x interface{}) error {
-	v := reflect.ValueOf(x)
-	if v.Kind() != reflect.Pointer {
-		return fmt.Errorf("%w: %T", ErrBadType, x)
-	}
-	v = v.Elem()
-	if v.Kind() != reflect.Struct {
-		return fmt.Errorf("%w: %T", ErrBadType, x)
-	}
-	var errs error
-	for _, f := range reflect.VisibleFields(v.Type()) {
-		fv := v.FieldByIndex(f.Index).Addr()
-		if x, ok := fv.Interface().(RequiredIface); ok {
-			if !x.HasValue() {
-				errs = errors.Join(errs, fmt.Errorf("field '%s' in '%s' is required", f.Name, v.Type()))
-			}
+	return defaultValidator.Struct(x)
+}
+
+// StructWithPrefix is like [Struct] but prefixes the paths of the fields in errors with the provided path,
+// so that errors of separately decoded parts of a larger structure can be combined into a single report.
+func StructWithPrefix(x interface{}, prefix string) error {
+	return defaultValidator.StructWithPrefix(x, prefix)
+}
+
+// StructConfigured is like [Struct] but only requires the fields mapped to true in requiredFields,
+// which may come from configuration, e.g. feature flags. See [WithRequiredFields].
+func StructConfigured(x interface{}, requiredFields map[string]bool) error {
+	return New(WithRequiredFields(requiredFields)).Struct(x)
+}
+
+// StructReport is like [Struct] but also returns the paths of the required fields which are present,
+// e.g. to save what can be saved of an incomplete request.
+func StructReport(x interface{}) (satisfied []string, err error) {
+	return defaultValidator.StructReport(x)
+}
+
+// FirstMissing returns the path of the first missing required field of the structure x points to
+// and whether any field is missing. It is the cheapest way to tell whether a structure is complete
+// as it stops at the first missing field and builds no error.
+func FirstMissing(x interface{}) (field string, ok bool) {
+	return defaultValidator.FirstMissing(x)
+}
+
+// StructG is a typed variant of [Struct] for generic code. The argument is statically a pointer,
+// though whether T is a structure type is still checked at run time.
+func StructG[T any](obj *T) error {
+	return defaultValidator.Struct(obj)
+}
+
+// ValidateAll validates each of the provided objects with [Struct] and joins the errors,
+// each of them prefixed with the type of the object it pertains to.
+func ValidateAll(objs ...interface{}) error {
+	var errs []error
+	for _, obj := range objs {
+		if err := Struct(obj); err != nil {
+			errs = append(errs, fmt.Errorf("%T: %w", obj, err))
 		}
 	}
-	return errs
+	return errors.Join(errs...)
+}
+
+// StructValue validates the structure, or a pointer to it, held by the reflection value.
+// It is useful to code operating on reflection values which would otherwise have to convert them to interfaces.
+// A structure which is not addressable is validated as a copy.
+func StructValue(v reflect.Value) error {
+	return defaultValidator.StructValue(v)
 }
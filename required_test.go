@@ -2,7 +2,11 @@ package validate
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -40,6 +44,16 @@ func TestRequiredPtr(t *testing.T) {
 	req.Equal(reflect.ValueOf(p.Name.Ptr()).UnsafePointer(), p.Name.UnsafePtr())
 }
 
+func TestSetValid(t *testing.T) {
+	req := require.New(t)
+
+	var r Required[int]
+	r.SetValid(true)
+	req.True(r.HasValue())
+	r.SetValid(false)
+	req.False(r.HasValue())
+}
+
 var gr interface{}
 
 func BenchmarkWithValidation(b *testing.B) {
@@ -70,3 +84,571 @@ func BenchmarkWithoutValidation(b *testing.B) {
 	}
 	gr = lr
 }
+
+func TestCoalesce(t *testing.T) {
+	req := require.New(t)
+
+	r := Coalesce[int]()
+	req.False(r.HasValue())
+
+	r = Coalesce(Required[int]{}, Required[int]{})
+	req.False(r.HasValue())
+
+	r = Coalesce(Required[int]{value: 1, valid: true}, Required[int]{value: 2, valid: true})
+	req.True(r.HasValue())
+	req.Equal(1, r.Value())
+
+	r = Coalesce(Required[int]{}, Required[int]{value: 2, valid: true}, Required[int]{value: 3, valid: true})
+	req.True(r.HasValue())
+	req.Equal(2, r.Value())
+}
+
+type withUnexported struct {
+	Name  Required[string] `json:"name"`
+	age   Required[int]
+	count int
+}
+
+type Base struct {
+	ID Required[int] `json:"id"`
+}
+
+type Derived struct {
+	*Base
+	Name Required[string] `json:"name"`
+}
+
+func TestStructIllTyped(t *testing.T) {
+	req := require.New(t)
+
+	err := Struct(map[string]interface{}{"name": "Saoirse"})
+	req.ErrorIs(err, ErrBadType)
+
+	err = Struct(nil)
+	req.ErrorIs(err, ErrBadType)
+
+	var p *Person
+	req.NotPanics(func() { err = Struct(p) })
+	req.ErrorIs(err, ErrBadType)
+	req.Equal("bad type: nil *validate.Person", err.Error())
+
+	var w withUnexported
+	err = json.Unmarshal([]byte(`{"name":"Saoirse"}`), &w)
+	req.NoError(err)
+	req.NotPanics(func() { err = Struct(&w) })
+	req.ErrorIs(err, ErrBadType)
+	req.Equal("field 'age' in 'validate.withUnexported' is unexported: bad type", err.Error())
+
+	var d Derived
+	err = json.Unmarshal([]byte(`{"name":"Saoirse"}`), &d)
+	req.NoError(err)
+	req.NotPanics(func() { err = Struct(&d) })
+	req.Equal("field 'ID' in 'validate.Derived' is required", err.Error())
+}
+
+func TestTry(t *testing.T) {
+	req := require.New(t)
+
+	r, err := Try(strconv.Atoi("25"))
+	req.NoError(err)
+	req.True(r.HasValue())
+	req.Equal(25, r.Value())
+
+	r, err = Try(strconv.Atoi("twenty-five"))
+	req.ErrorIs(err, strconv.ErrSyntax)
+	req.False(r.HasValue())
+}
+
+type Temperature float64
+
+func (t Temperature) String() string { return strconv.FormatFloat(float64(t), 'f', 1, 64) + "°C" }
+
+type Point struct{ X, Y int }
+
+type Grid struct{ Rows, Cols int }
+
+func (g *Grid) String() string { return strconv.Itoa(g.Rows) + "x" + strconv.Itoa(g.Cols) }
+
+func TestString(t *testing.T) {
+	req := require.New(t)
+
+	var unset Required[int]
+	req.Equal("N/A", unset.String())
+
+	i := Required[int]{value: 5, valid: true}
+	req.Equal("5", i.String())
+
+	temp := Required[Temperature]{value: 21.5, valid: true}
+	req.Equal("21.5°C", temp.String())
+
+	tempPtr := Required[*Temperature]{value: &temp.value, valid: true}
+	req.Equal("21.5°C", tempPtr.String())
+
+	pt := Required[*Point]{value: &Point{X: 1, Y: 2}, valid: true}
+	req.Equal("{1 2}", pt.String())
+
+	grid := Required[Grid]{value: Grid{Rows: 3, Cols: 4}, valid: true}
+	req.Equal("3x4", grid.String())
+	gridPtr := Required[*Grid]{value: &grid.value, valid: true}
+	req.Equal("3x4", gridPtr.String())
+
+	var nilPt Required[*Point]
+	req.NoError(json.Unmarshal([]byte(`null`), &nilPt))
+	req.Equal("<nil>", nilPt.String())
+}
+
+type FastPerson struct {
+	Person
+	calls int
+}
+
+func (p *FastPerson) ValidateRequired() error {
+	p.calls++
+	if !p.Name.HasValue() {
+		return errors.New("name is missing")
+	}
+	return nil
+}
+
+func TestStructFastValidatable(t *testing.T) {
+	req := require.New(t)
+
+	var p FastPerson
+	err := json.Unmarshal([]byte(`{"age":25}`), &p)
+	req.NoError(err)
+
+	err = Struct(&p)
+	req.EqualError(err, "name is missing")
+	req.Equal(1, p.calls)
+
+	err = json.Unmarshal([]byte(`{"name":"Saoirse"}`), &p)
+	req.NoError(err)
+	err = Struct(&p)
+	req.NoError(err)
+	req.Equal(2, p.calls)
+}
+
+// EmbeddingFast has the ValidateRequired method of FastPerson only by embedding it.
+type EmbeddingFast struct {
+	FastPerson
+	Other Required[int] `json:"other"`
+}
+
+func TestStructPromotedFastValidatable(t *testing.T) {
+	req := require.New(t)
+
+	var p EmbeddingFast
+	req.NoError(json.Unmarshal([]byte(`{"name":"Saoirse","age":25}`), &p))
+	req.EqualError(Struct(&p), "field 'Other' in 'validate.EmbeddingFast' is required")
+	req.Zero(p.calls)
+
+	p.Other.Set(1)
+	req.NoError(Struct(&p))
+}
+
+func TestSource(t *testing.T) {
+	req := require.New(t)
+
+	var r Required[int]
+	req.Equal(SourceNone, r.Source())
+
+	req.NoError(json.Unmarshal([]byte(`25`), &r))
+	req.Equal(SourceJSON, r.Source())
+
+	r.Set(26)
+	req.True(r.HasValue())
+	req.Equal(26, r.Value())
+	req.Equal(SourceManual, r.Source())
+
+	req.NoError(r.Scan(int64(27)))
+	req.Equal(SourceSQL, r.Source())
+	req.NoError(r.Scan(nil))
+	req.Equal(SourceNone, r.Source())
+
+	r.SetValid(true)
+	req.Equal(SourceManual, r.Source())
+	r.SetValid(false)
+	req.False(r.HasValue())
+	req.Equal(SourceNone, r.Source())
+
+	r, err := Try(strconv.Atoi("28"))
+	req.NoError(err)
+	req.Equal(SourceManual, r.Source())
+	req.Equal("manual", r.Source().String())
+}
+
+func TestCompactPartition(t *testing.T) {
+	req := require.New(t)
+
+	var (
+		a, b, c Required[string]
+		unset   Required[string]
+	)
+	a.Set("a")
+	b.Set("b")
+	c.Set("c")
+
+	req.Equal([]string{"a", "b", "c"}, Compact([]Required[string]{a, b, c}))
+	req.Equal([]string{"a", "c"}, Compact([]Required[string]{unset, a, unset, c}))
+	req.Empty(Compact([]Required[string]{unset, unset}))
+	req.Empty(Compact[string](nil))
+
+	valid, missing := Partition([]Required[string]{a, b, c})
+	req.Equal([]string{"a", "b", "c"}, valid)
+	req.Equal(0, missing)
+
+	valid, missing = Partition([]Required[string]{b, unset, a, unset})
+	req.Equal([]string{"b", "a"}, valid)
+	req.Equal(2, missing)
+
+	valid, missing = Partition([]Required[string]{unset, unset, unset})
+	req.Empty(valid)
+	req.Equal(3, missing)
+}
+
+type Attachment struct {
+	Name Required[string] `json:"name"`
+	Data Required[[]byte] `json:"data"`
+}
+
+func TestRequiredBytes(t *testing.T) {
+	req := require.New(t)
+
+	var a Attachment
+	err := json.Unmarshal([]byte(`{"name":"hello.txt","data":"aGVsbG8="}`), &a)
+	req.NoError(err)
+	req.NoError(Struct(&a))
+	req.Equal([]byte("hello"), a.Data.Value())
+
+	a = Attachment{}
+	err = json.Unmarshal([]byte(`{"name":"empty.txt","data":""}`), &a)
+	req.NoError(err)
+	req.NoError(Struct(&a))
+	req.True(a.Data.HasValue())
+	req.Equal([]byte{}, a.Data.Value())
+
+	a = Attachment{}
+	err = json.Unmarshal([]byte(`{"name":"null.txt","data":null}`), &a)
+	req.NoError(err)
+	req.NoError(Struct(&a))
+	req.True(a.Data.HasValue())
+	req.Nil(a.Data.Value())
+
+	a = Attachment{}
+	err = json.Unmarshal([]byte(`{"name":"bad.txt","data":"!!!"}`), &a)
+	req.Error(err)
+	req.False(a.Data.HasValue())
+
+	a = Attachment{}
+	err = json.Unmarshal([]byte(`{"name":"missing.txt"}`), &a)
+	req.NoError(err)
+	req.Equal("field 'Data' in 'validate.Attachment' is required", Struct(&a).Error())
+}
+
+func TestIsPresentZero(t *testing.T) {
+	req := require.New(t)
+
+	var p Person
+	err := json.Unmarshal([]byte(`{"name":"","age":25}`), &p)
+	req.NoError(err)
+
+	var fields []RequiredIface
+	for _, x := range []interface{}{&p.Name, &p.Age} {
+		fields = append(fields, x.(RequiredIface))
+	}
+	req.True(fields[0].IsPresentZero())
+	req.False(fields[1].IsPresentZero())
+
+	var unset Required[int]
+	req.False(unset.IsPresentZero())
+}
+
+func TestValidateAll(t *testing.T) {
+	req := require.New(t)
+
+	p := Person{Name: NewRequired("Saoirse"), Age: NewRequired(25)}
+	a := Address{Street: NewRequired("Main")}
+
+	req.NoError(ValidateAll(&p))
+	req.NoError(ValidateAll())
+
+	err := ValidateAll(&p, &a)
+	req.Equal("*validate.Address: field 'Zip' in 'validate.Address' is required", err.Error())
+
+	err = ValidateAll(&a, p)
+	req.ErrorIs(err, ErrBadType)
+	req.ErrorIs(err, ErrRequired)
+	req.Equal("*validate.Address: field 'Zip' in 'validate.Address' is required\n"+
+		"validate.Person: bad type: validate.Person", err.Error())
+}
+
+func TestKind(t *testing.T) {
+	req := require.New(t)
+
+	var (
+		s  Required[string]
+		n  Required[int]
+		xs Required[[]int]
+		p  Required[Point]
+		ns Required[Nickname]
+	)
+	for _, c := range []struct {
+		x    RequiredIface
+		kind reflect.Kind
+	}{
+		{&s, reflect.String},
+		{&n, reflect.Int},
+		{&xs, reflect.Slice},
+		{&p, reflect.Struct},
+		{&ns, reflect.String},
+	} {
+		req.Equal(c.kind, c.x.Kind())
+		req.Equal(c.x.RequiredType().Kind(), c.x.Kind())
+	}
+}
+
+func TestStructG(t *testing.T) {
+	req := require.New(t)
+
+	for _, payload := range []string{`{"name":"Saoirse","age":25}`, `{"name":"Saoirse"}`, `{}`} {
+		var p Person
+		req.NoError(json.Unmarshal([]byte(payload), &p))
+		req.Equal(Struct(&p), StructG(&p), payload)
+	}
+
+	var p *Person
+	req.Equal(Struct(p), StructG(p))
+	n := 5
+	req.ErrorIs(StructG(&n), ErrBadType)
+}
+
+func TestApply(t *testing.T) {
+	req := require.New(t)
+
+	var a Required[Address]
+	req.NoError(json.Unmarshal([]byte(`{"street":" Main ","zip":"12345"}`), &a))
+	a.Apply(func(addr *Address) { addr.Street.Set(strings.TrimSpace(addr.Street.Get())) })
+	addr := a.Get()
+	req.Equal("Main", addr.Street.Get())
+	req.Equal("12345", addr.Zip.Get())
+
+	var n Required[int]
+	called := false
+	n.Apply(func(*int) { called = true })
+	req.False(called)
+	req.False(n.HasValue())
+	req.Equal(0, n.Get())
+
+	n.Set(1)
+	n.Apply(func(v *int) { *v++ })
+	req.Equal(2, n.Get())
+	n.freeze()
+	n.Apply(func(v *int) { *v++ })
+	req.Equal(2, n.Get())
+}
+
+func TestClear(t *testing.T) {
+	req := require.New(t)
+
+	var c Comment
+	req.NoError(json.Unmarshal([]byte(`{"text":"Hello","user":"u1"}`), &c))
+	req.NoError(Struct(&c))
+
+	var fields []RequiredIface
+	v := reflect.ValueOf(&c).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if x, ok := v.Field(i).Addr().Interface().(RequiredIface); ok {
+			fields = append(fields, x)
+		}
+	}
+	req.Len(fields, 2)
+	for _, f := range fields {
+		f.Clear()
+		req.False(f.HasValue())
+	}
+	req.Equal("", c.Text.Get())
+	req.False(c.Text.Touched())
+	req.Equal(SourceNone, c.Text.Source())
+	req.Equal(0, c.User.Variant())
+	err := Struct(&c)
+	req.Equal("field 'Text' in 'validate.Comment' is required\nfield 'User' in 'validate.Comment' is required", err.Error())
+
+	n := NewRequired(5)
+	n.freeze()
+	n.Clear()
+	req.Equal(5, n.Get())
+}
+
+type PatchPerson struct {
+	Name  *Required[string] `json:"name"`
+	Age   *Required[int]    `json:"age"`
+	Title *Required[string] `json:"title" required_if:"Name=Dr"`
+}
+
+func TestRequiredPtrFields(t *testing.T) {
+	req := require.New(t)
+
+	p := PatchPerson{Name: NewRequiredPtr("Saoirse"), Age: NewRequiredPtr(25), Title: NilRequired[string]()}
+	req.NoError(Struct(&p))
+	req.Nil(p.Title)
+
+	p.Age = NilRequired[int]()
+	err := Struct(&p)
+	req.Equal("field 'Age' in 'validate.PatchPerson' is required", err.Error())
+
+	p = PatchPerson{}
+	req.NoError(json.Unmarshal([]byte(`{"name":"Saoirse","age":null}`), &p))
+	err = Struct(&p)
+	req.Equal("field 'Age' in 'validate.PatchPerson' is required", err.Error())
+
+	p = PatchPerson{Name: NewRequiredPtr("Dr"), Age: &Required[int]{}}
+	err = Struct(&p)
+	req.Equal("field 'Age' in 'validate.PatchPerson' is required\nfield 'Title' in 'validate.PatchPerson' is required", err.Error())
+}
+
+func TestState(t *testing.T) {
+	req := require.New(t)
+
+	var c struct {
+		Name  Required[string]  `json:"name"`
+		Email Required[*string] `json:"email"`
+		Phone Required[string]  `json:"phone"`
+	}
+	req.NoError(json.Unmarshal([]byte(`{"name":"Saoirse","email":null}`), &c))
+
+	name, present, wasNull := c.Name.State()
+	req.Equal("Saoirse", name)
+	req.True(present)
+	req.False(wasNull)
+
+	email, present, wasNull := c.Email.State()
+	req.Nil(email)
+	req.True(present)
+	req.True(wasNull)
+
+	phone, present, wasNull := c.Phone.State()
+	req.Equal("", phone)
+	req.False(present)
+	req.False(wasNull)
+
+	_, present, _ = NewRequired(5).State()
+	req.True(present)
+}
+
+func TestSamePresence(t *testing.T) {
+	req := require.New(t)
+
+	req.True(NewRequired(1).SamePresence(NewRequired(2)))
+	req.True(Required[int]{}.SamePresence(Required[int]{}))
+	req.False(NewRequired(1).SamePresence(Required[int]{}))
+	req.False(Required[int]{}.SamePresence(NewRequired(0)))
+
+	var before, after Person
+	req.NoError(json.Unmarshal([]byte(`{"name":"Saoirse"}`), &before))
+	req.NoError(json.Unmarshal([]byte(`{"name":"Siobhan","age":25}`), &after))
+	req.True(before.Name.SamePresence(after.Name))
+	req.False(before.Age.SamePresence(after.Age))
+}
+
+// Quantity accepts numbers as well as numeric strings and treats null as zero.
+type Quantity int
+
+func (q *Quantity) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if s == "null" {
+		*q = 0
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("bad quantity %s", b)
+	}
+	*q = Quantity(n)
+	return nil
+}
+
+// Strict rejects null.
+type Strict string
+
+func (s *Strict) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return errors.New("null is not allowed")
+	}
+	return json.Unmarshal(b, (*string)(s))
+}
+
+type Line struct {
+	Qty  Required[Quantity] `json:"qty"`
+	Code Required[Strict]   `json:"code"`
+}
+
+func TestRequiredCustomUnmarshaler(t *testing.T) {
+	req := require.New(t)
+
+	for _, qty := range []string{`3`, `"3"`} {
+		var l Line
+		req.NoError(json.Unmarshal([]byte(`{"qty":`+qty+`,"code":"A1"}`), &l), qty)
+		req.NoError(Struct(&l))
+		req.Equal(Quantity(3), l.Qty.Get())
+		req.Equal(Strict("A1"), l.Code.Get())
+	}
+
+	var l Line
+	req.NoError(json.Unmarshal([]byte(`{"code":"A1"}`), &l))
+	req.False(l.Qty.HasValue())
+	req.Equal("field 'Qty' in 'validate.Line' is required", Struct(&l).Error())
+
+	l = Line{}
+	req.NoError(json.Unmarshal([]byte(`{"qty":null,"code":"A1"}`), &l))
+	req.True(l.Qty.HasValue())
+	req.True(l.Qty.WasNull())
+	req.Equal(Quantity(0), l.Qty.Get())
+	req.NoError(Struct(&l))
+
+	l = Line{}
+	err := json.Unmarshal([]byte(`{"qty":"three","code":"A1"}`), &l)
+	req.EqualError(err, `bad quantity "three"`)
+	req.False(l.Qty.HasValue())
+
+	l = Line{}
+	err = json.Unmarshal([]byte(`{"qty":1,"code":null}`), &l)
+	req.EqualError(err, "null is not allowed")
+	req.False(l.Code.HasValue())
+}
+
+func TestGoString(t *testing.T) {
+	req := require.New(t)
+
+	s := NewRequired("a b")
+	req.Equal(`Required[string]("a b")`, fmt.Sprintf("%#v", &s))
+	n := NewRequired(5)
+	req.Equal(`Required[int](5)`, fmt.Sprintf("%#v", &n))
+	var unset Required[string]
+	req.Equal(`Required[string](unset)`, fmt.Sprintf("%#v", &unset))
+	pt := NewRequired(&Point{X: 1})
+	req.Equal(`Required[*validate.Point](&validate.Point{X:1, Y:0})`, pt.GoString())
+	req.Equal("a b", s.String())
+}
+
+func TestIsStruct(t *testing.T) {
+	req := require.New(t)
+
+	var (
+		s   Required[string]
+		a   Required[Address]
+		pa  Required[*Address]
+		as  Required[[]Address]
+		ref RequiredOneOf[string, UserRef]
+	)
+	req.False(s.IsStruct())
+	req.True(a.IsStruct())
+	req.True(pa.IsStruct())
+	req.False(as.IsStruct())
+
+	req.False(ref.IsStruct())
+	req.NoError(json.Unmarshal([]byte(`{"id":"u1"}`), &ref))
+	req.True(ref.IsStruct())
+}
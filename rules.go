@@ -0,0 +1,133 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// ruleTags are the tags holding the constraints checked by [checkRules].
+var ruleTags = [...]string{"after", "before", "min", "max", "pattern"}
+
+// patterns caches the compiled regular expressions of the `pattern` tags.
+var patterns sync.Map // map[string]*regexp.Regexp
+
+// checkRules checks the value of a field against the constraints given by the tags of the field.
+// Fields of type [time.Time] can be constrained by `after` and `before` tags whose values are
+// either `now` or timestamps in the RFC 3339 or the date-only format.
+// Numeric fields can be constrained by `min` and `max` tags bounding their values,
+// strings, slices, arrays and maps by the same tags bounding their lengths.
+// Strings can be constrained by a `pattern` tag holding a regular expression they have to match.
+func checkRules(tag reflect.StructTag, v reflect.Value) error {
+	for _, key := range [...]string{"after", "before"} {
+		bound, ok := tag.Lookup(key)
+		if !ok {
+			continue
+		}
+		if v.Type() != timeType {
+			return malformedTag(key, bound)
+		}
+		b, err := parseTimeBound(bound)
+		if err != nil {
+			return malformedTag(key, bound)
+		}
+		t := v.Interface().(time.Time)
+		if key == "after" && !t.After(b) || key == "before" && !t.Before(b) {
+			return fmt.Errorf("%w: must be %s %s", ErrInvalid, key, bound)
+		}
+	}
+	for _, key := range [...]string{"min", "max"} {
+		bound, ok := tag.Lookup(key)
+		if !ok {
+			continue
+		}
+		b, err := strconv.ParseFloat(bound, 64)
+		if err != nil {
+			return malformedTag(key, bound)
+		}
+		n, length, ok := magnitude(v)
+		if !ok {
+			return malformedTag(key, bound)
+		}
+		if key == "min" && n >= b || key == "max" && n <= b {
+			continue
+		}
+		limit := "at least"
+		if key == "max" {
+			limit = "at most"
+		}
+		if length {
+			return fmt.Errorf("%w: must have a length of %s %s", ErrInvalid, limit, bound)
+		}
+		return fmt.Errorf("%w: must be %s %s", ErrInvalid, limit, bound)
+	}
+	if expr, ok := tag.Lookup("pattern"); ok {
+		if v.Kind() != reflect.String {
+			return malformedTag("pattern", expr)
+		}
+		re, err := compilePattern(expr)
+		if err != nil {
+			return malformedTag("pattern", expr)
+		}
+		if !re.MatchString(v.String()) {
+			return fmt.Errorf("%w: must match %s", ErrInvalid, expr)
+		}
+	}
+	return nil
+}
+
+// hasRules tells whether the tag constrains the field by any of the tags checked by [checkRules].
+func hasRules(tag reflect.StructTag) bool {
+	for _, key := range ruleTags {
+		if _, ok := tag.Lookup(key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// magnitude returns the number compared against the `min` and `max` bounds,
+// which is the value of a number or the length of a string or a collection.
+func magnitude(v reflect.Value) (n float64, length bool, ok bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), false, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), false, true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), false, true
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), true, true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(v.Len()), true, true
+	}
+	return 0, false, false
+}
+
+func compilePattern(expr string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(expr); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	patterns.Store(expr, re)
+	return re, nil
+}
+
+func parseTimeBound(s string) (time.Time, error) {
+	if s == "now" {
+		return time.Now(), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, s)
+}
@@ -0,0 +1,103 @@
+package validate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Booking struct {
+	Start Required[time.Time] `json:"start" after:"now"`
+	End   Required[time.Time] `json:"end" before:"2030-01-01"`
+}
+
+func TestTimeRules(t *testing.T) {
+	req := require.New(t)
+
+	var b Booking
+	b.Start.Set(time.Now().Add(time.Hour))
+	b.End.Set(time.Date(2029, 12, 31, 0, 0, 0, 0, time.UTC))
+	req.NoError(Struct(&b))
+
+	b.Start.Set(time.Now().Add(-time.Hour))
+	b.End.Set(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	err := Struct(&b)
+	req.ErrorIs(err, ErrInvalid)
+	req.Equal("field 'Start' in 'validate.Booking' is invalid: must be after now\n"+
+		"field 'End' in 'validate.Booking' is invalid: must be before 2030-01-01", err.Error())
+
+	// the rules do not apply to missing fields
+	b = Booking{}
+	err = Struct(&b)
+	req.Equal("field 'Start' in 'validate.Booking' is required\nfield 'End' in 'validate.Booking' is required", err.Error())
+}
+
+type badTimeRules struct {
+	Count Required[int]       `after:"now"`
+	At    Required[time.Time] `before:"soon"`
+}
+
+func TestTimeRulesMalformed(t *testing.T) {
+	req := require.New(t)
+
+	var b badTimeRules
+	b.Count.Set(1)
+	b.At.Set(time.Now())
+	err := Struct(&b)
+	req.ErrorIs(err, ErrBadType)
+	req.Equal("field 'Count' in 'validate.badTimeRules' has a malformed tag after:\"now\": bad type\n"+
+		"field 'At' in 'validate.badTimeRules' has a malformed tag before:\"soon\": bad type", err.Error())
+}
+
+type Listing struct {
+	Title    Required[string] `json:"title" max:"10"`
+	Price    Required[int]    `json:"price" min:"1" max:"1000"`
+	Code     Required[string] `json:"code" pattern:"^[A-Z]{3}$"`
+	Slug     string           `json:"slug" pattern:"^[a-z-]+$"`
+	Discount *float64         `json:"discount" max:"0.5"`
+	Tags     []string         `json:"tags" max:"2"`
+}
+
+func TestRulesAllFields(t *testing.T) {
+	req := require.New(t)
+
+	var l Listing
+	err := ParseBytes([]byte(`{"title":"a very long title","code":"ABC","slug":"Not A Slug"}`), &l)
+	req.ErrorIs(err, ErrRequired)
+	req.ErrorIs(err, ErrInvalid)
+	req.Equal("field 'Title' in 'validate.Listing' is invalid: must have a length of at most 10\n"+
+		"field 'Price' in 'validate.Listing' is required\n"+
+		"field 'Slug' in 'validate.Listing' is invalid: must match ^[a-z-]+$", err.Error())
+
+	l = Listing{}
+	err = ParseBytes([]byte(`{"title":"lamp","price":0,"code":"ab","discount":0.75,"tags":["a","b","c"]}`), &l)
+	req.Equal("field 'Price' in 'validate.Listing' is invalid: must be at least 1\n"+
+		"field 'Code' in 'validate.Listing' is invalid: must match ^[A-Z]{3}$\n"+
+		"field 'Discount' in 'validate.Listing' is invalid: must be at most 0.5\n"+
+		"field 'Tags' in 'validate.Listing' is invalid: must have a length of at most 2", err.Error())
+
+	// absent optional fields are not checked
+	l = Listing{}
+	req.NoError(ParseBytes([]byte(`{"title":"lamp","price":25,"code":"LMP"}`), &l))
+}
+
+type badRules struct {
+	Name  Required[string] `min:"few"`
+	Count Required[int]    `pattern:"[0-9]+"`
+	Code  string           `pattern:"("`
+}
+
+func TestRulesMalformed(t *testing.T) {
+	req := require.New(t)
+
+	var b badRules
+	b.Name.Set("x")
+	b.Count.Set(1)
+	b.Code = "x"
+	err := Struct(&b)
+	req.ErrorIs(err, ErrBadType)
+	req.Equal("field 'Name' in 'validate.badRules' has a malformed tag min:\"few\": bad type\n"+
+		"field 'Count' in 'validate.badRules' has a malformed tag pattern:\"[0-9]+\": bad type\n"+
+		"field 'Code' in 'validate.badRules' has a malformed tag pattern:\"(\": bad type", err.Error())
+}
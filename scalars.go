@@ -0,0 +1,34 @@
+package validate
+
+import (
+	"reflect"
+	"time"
+)
+
+// scalarPresence tells whether x, which is a pointer to one of the common instantiations of [Required]
+// over scalar types, has a value. It reports false as the second result for any other type.
+// It lets the walker skip the dynamic dispatch and the reflection of the general path.
+func scalarPresence(x interface{}) (present bool, ok bool) {
+	switch x := x.(type) {
+	case *Required[string]:
+		return x.HasValue(), true
+	case *Required[int]:
+		return x.HasValue(), true
+	case *Required[int64]:
+		return x.HasValue(), true
+	case *Required[bool]:
+		return x.HasValue(), true
+	case *Required[float64]:
+		return x.HasValue(), true
+	case *Required[time.Time]:
+		return x.HasValue(), true
+	}
+	return false, false
+}
+
+// scalar tells whether the presence of a scalar field with the tag can be checked by [scalarPresence] alone,
+// that is, whether no option or tag requires the value to be inspected.
+func (w *walker) scalar(tag reflect.StructTag) bool {
+	vd := w.vd
+	return !vd.zeroMissing && !vd.emptyMissing && !vd.blankMissing && vd.nullWarning == nil && !hasRules(tag)
+}
@@ -0,0 +1,93 @@
+package validate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Scalars struct {
+	Name     Required[string]
+	Email    Required[string]
+	Age      Required[int]
+	Count    Required[int]
+	ID       Required[int64]
+	Version  Required[int64]
+	Active   Required[bool]
+	Admin    Required[bool]
+	Score    Required[float64]
+	Ratio    Required[float64]
+	Created  Required[time.Time]
+	Modified Required[time.Time]
+}
+
+// OtherScalars is like Scalars but its fields are not covered by the fast path.
+type OtherScalars struct {
+	Name     Required[[]byte]
+	Email    Required[[]byte]
+	Age      Required[int32]
+	Count    Required[int32]
+	ID       Required[uint64]
+	Version  Required[uint64]
+	Active   Required[uint8]
+	Admin    Required[uint8]
+	Score    Required[float32]
+	Ratio    Required[float32]
+	Created  Required[time.Duration]
+	Modified Required[time.Duration]
+}
+
+func TestScalarFastPath(t *testing.T) {
+	req := require.New(t)
+
+	var s Scalars
+	s.Name.Set("Saoirse")
+	s.Age.Set(0)
+	s.Active.Set(false)
+	s.Created.Set(time.Now())
+	err := Struct(&s)
+	req.Equal(`field 'Email' in 'validate.Scalars' is required
+field 'Count' in 'validate.Scalars' is required
+field 'ID' in 'validate.Scalars' is required
+field 'Version' in 'validate.Scalars' is required
+field 'Admin' in 'validate.Scalars' is required
+field 'Score' in 'validate.Scalars' is required
+field 'Ratio' in 'validate.Scalars' is required
+field 'Modified' in 'validate.Scalars' is required`, err.Error())
+
+	// the options are honoured
+	err = New(WithZeroAsMissing(), WithSkipFields("Email", "Count", "ID", "Version", "Admin", "Score", "Ratio", "Modified")).Struct(&s)
+	req.Equal("field 'Age' in 'validate.Scalars' is required\nfield 'Active' in 'validate.Scalars' is required", err.Error())
+
+	present, ok := scalarPresence(&s.Name)
+	req.True(ok)
+	req.True(present)
+	_, ok = scalarPresence(&Required[int32]{})
+	req.False(ok)
+}
+
+func BenchmarkStructScalars(b *testing.B) {
+	b.Run("fast", func(b *testing.B) {
+		s := Scalars{
+			NewRequired(""), NewRequired(""), NewRequired(0), NewRequired(0), NewRequired(int64(0)), NewRequired(int64(0)),
+			NewRequired(false), NewRequired(false), NewRequired(0.0), NewRequired(0.0), NewRequired(time.Time{}), NewRequired(time.Time{}),
+		}
+		for i := 0; i < b.N; i++ {
+			if err := Struct(&s); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("fallback", func(b *testing.B) {
+		s := OtherScalars{
+			NewRequired([]byte{}), NewRequired([]byte{}), NewRequired(int32(0)), NewRequired(int32(0)), NewRequired(uint64(0)), NewRequired(uint64(0)),
+			NewRequired(uint8(0)), NewRequired(uint8(0)), NewRequired(float32(0)), NewRequired(float32(0)), NewRequired(time.Duration(0)), NewRequired(time.Duration(0)),
+		}
+		for i := 0; i < b.N; i++ {
+			if err := Struct(&s); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// objectMembers calls fn for each member of the JSON object b in the order of their appearance.
+// It does nothing if b is not an object.
+func objectMembers(b []byte, fn func(key string, value json.RawMessage) error) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		if err := fn(tok.(string), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
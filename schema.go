@@ -0,0 +1,113 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// schema is a minimal JSON Schema.
+type schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Items                *schema            `json:"items,omitempty"`
+	Properties           map[string]*schema `json:"properties,omitempty"`
+	AdditionalProperties *schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Deprecated           bool               `json:"deprecated,omitempty"`
+}
+
+// JSONSchema returns a minimal JSON Schema of the structure x is or points to, e.g. for generating documentation.
+// The fields are named by their JSON names and their types are mapped to JSON types; the fields of type [Required]
+// are described by their underlying types and listed as required unless they are conditionally required
+// or of type [Deprecated]. Fields of type [Forbidden] are omitted. Recursive types are described as any value
+// where they recur.
+func JSONSchema(x interface{}) ([]byte, error) {
+	t := reflect.TypeOf(x)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %T", ErrBadType, x)
+	}
+	s := typeSchema(t, make(map[reflect.Type]bool))
+	s.Schema = "https://json-schema.org/draft/2020-12/schema"
+	return json.Marshal(s)
+}
+
+func typeSchema(t reflect.Type, visiting map[reflect.Type]bool) *schema {
+	if t == timeType {
+		return &schema{Type: "string", Format: "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return &schema{Type: "string"}
+	case reflect.Bool:
+		return &schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &schema{Type: "number"}
+	case reflect.Pointer:
+		return typeSchema(t.Elem(), visiting)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoded in base64
+			return &schema{Type: "string"}
+		}
+		return &schema{Type: "array", Items: typeSchema(t.Elem(), visiting)}
+	case reflect.Map:
+		return &schema{Type: "object", AdditionalProperties: typeSchema(t.Elem(), visiting)}
+	case reflect.Struct:
+		return structSchema(t, visiting)
+	}
+	return &schema{}
+}
+
+func structSchema(t reflect.Type, visiting map[reflect.Type]bool) *schema {
+	if visiting[t] {
+		return &schema{}
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+	s := &schema{Type: "object", Properties: make(map[string]*schema)}
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous && indirectType(f.Type).Kind() == reflect.Struct {
+			continue
+		}
+		if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name == "-" {
+			continue
+		}
+		pt := reflect.PointerTo(indirectType(f.Type))
+		if pt.Implements(forbiddenIfaceType) {
+			continue
+		}
+		name := jsonName(f)
+		switch {
+		case pt.Implements(RequiredIfaceType):
+			x := reflect.New(pt.Elem()).Interface().(RequiredIface)
+			ps := typeSchema(x.RequiredType(), visiting)
+			_, deprecated := x.(deprecatedIface)
+			_, conditional := f.Tag.Lookup("required_if")
+			if _, unless := f.Tag.Lookup("required_unless"); unless {
+				conditional = true
+			}
+			switch {
+			case deprecated:
+				ps.Deprecated = true
+			case !conditional:
+				s.Required = append(s.Required, name)
+			}
+			s.Properties[name] = ps
+		case pt.Implements(requiredContainerType):
+			s.Properties[name] = &schema{}
+			s.Required = append(s.Required, name)
+		default:
+			s.Properties[name] = typeSchema(f.Type, visiting)
+		}
+	}
+	return s
+}
@@ -0,0 +1,76 @@
+package validate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Membership struct {
+	ID      Forbidden[string]    `json:"id"`
+	Member  Required[Person]     `json:"member"`
+	Since   Required[time.Time]  `json:"since"`
+	Roles   []string             `json:"roles"`
+	Quota   *float64             `json:"quota"`
+	Labels  map[string]int       `json:"labels"`
+	Reason  Required[string]     `json:"reason" required_if:"Kind=guest"`
+	Kind    Required[string]     `json:"kind"`
+	Legacy  Deprecated[bool]     `json:"legacy"`
+	Refs    Required[[]*UserRef] `json:"refs"`
+	Comment string               `json:"-"`
+	Tree    Tree                 `json:"tree"`
+}
+
+func TestJSONSchema(t *testing.T) {
+	req := require.New(t)
+
+	b, err := JSONSchema(&Person{})
+	req.NoError(err)
+	req.JSONEq(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {"name": {"type": "string"}, "age": {"type": "integer"}},
+		"required": ["name", "age"]
+	}`, string(b))
+
+	b, err = JSONSchema(Membership{})
+	req.NoError(err)
+	req.JSONEq(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"member": {
+				"type": "object",
+				"properties": {"name": {"type": "string"}, "age": {"type": "integer"}},
+				"required": ["name", "age"]
+			},
+			"since": {"type": "string", "format": "date-time"},
+			"roles": {"type": "array", "items": {"type": "string"}},
+			"quota": {"type": "number"},
+			"labels": {"type": "object", "additionalProperties": {"type": "integer"}},
+			"reason": {"type": "string"},
+			"kind": {"type": "string"},
+			"legacy": {"type": "boolean", "deprecated": true},
+			"refs": {"type": "array", "items": {
+				"type": "object",
+				"properties": {"id": {"type": "string"}},
+				"required": ["id"]
+			}},
+			"tree": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"children": {"type": "array", "items": {}}
+				},
+				"required": ["name"]
+			}
+		},
+		"required": ["member", "since", "kind", "refs"]
+	}`, string(b))
+
+	_, err = JSONSchema(3)
+	req.ErrorIs(err, ErrBadType)
+	_, err = JSONSchema(nil)
+	req.ErrorIs(err, ErrBadType)
+}
@@ -0,0 +1,82 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// ValidateSlice validates every element of the slice, which must be a structure or a pointer to one.
+// The returned error is a [ValidationError] whose field paths are prefixed with the element index, e.g. `[2].Name`.
+func ValidateSlice[T any](items []T) error {
+	if err := checkElemType[T](); err != nil {
+		return err
+	}
+	var w walker
+	for i := range items {
+		validateElement(&w, defaultValidator, reflect.ValueOf(&items[i]).Elem(), i)
+	}
+	return w.err()
+}
+
+// ValidateSliceParallel is like [ValidateSlice] but validates the elements using a pool of workers.
+// If workers is not positive, [runtime.GOMAXPROCS] workers are used.
+// The field errors are ordered by element index regardless of the scheduling of the workers.
+func ValidateSliceParallel[T any](items []T, workers int) error {
+	if err := checkElemType[T](); err != nil {
+		return err
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(items))
+	results := make([]walker, len(items))
+	chunk := (len(items) + workers - 1) / max(workers, 1)
+	var wg sync.WaitGroup
+	for lo := 0; lo < len(items); lo += chunk {
+		hi := min(lo+chunk, len(items))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := lo; i < hi; i++ {
+				validateElement(&results[i], defaultValidator, reflect.ValueOf(&items[i]).Elem(), i)
+			}
+		}()
+	}
+	wg.Wait()
+	var w walker
+	for _, r := range results {
+		w.errs = append(w.errs, r.errs...)
+	}
+	return w.err()
+}
+
+func checkElemType[T any]() error {
+	if t := reflect.TypeFor[T](); indirectType(t).Kind() != reflect.Struct {
+		return fmt.Errorf("%w: []%s", ErrBadType, t)
+	}
+	return nil
+}
+
+// validateElement validates the element of a slice at index i, collecting the errors in w.
+func validateElement(w *walker, vd *Validator, v reflect.Value, i int) {
+	index := strconv.Itoa(i)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			w.errs = append(w.errs, &FieldError{Type: v.Type().Elem().String(), Field: "[" + index + "]", Pointer: "/" + index, Err: ErrRequired})
+			return
+		}
+		v = v.Elem()
+	}
+	w.vd, w.typ = vd, v.Type().String()
+	w.structure(v, "["+index+"]", "/"+index)
+}
+
+func fieldErrors(errs []*FieldError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: errs}
+}
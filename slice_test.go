@@ -0,0 +1,75 @@
+package validate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func people(n int) []Person {
+	ps := make([]Person, n)
+	for i := range ps {
+		ps[i].Name = Required[string]{value: fmt.Sprint("person", i), valid: true}
+		if i%100 != 7 {
+			ps[i].Age = Required[int]{value: i, valid: true}
+		}
+	}
+	return ps
+}
+
+func TestValidateSlice(t *testing.T) {
+	req := require.New(t)
+
+	err := ValidateSlice(people(3))
+	req.NoError(err)
+
+	err = ValidateSlice(people(8))
+	req.Equal("field '[7].Age' in 'validate.Person' is required", err.Error())
+
+	ps := people(2)
+	err = ValidateSlice([]*Person{&ps[0], nil})
+	req.Equal("field '[1]' in 'validate.Person' is required", err.Error())
+
+	err = ValidateSlice([]int{1})
+	req.ErrorIs(err, ErrBadType)
+}
+
+func TestValidateSliceParallel(t *testing.T) {
+	req := require.New(t)
+
+	ps := people(5000)
+	seq := ValidateSlice(ps)
+	req.Error(seq)
+
+	for _, workers := range []int{0, 1, 3, 16} {
+		err := ValidateSliceParallel(ps, workers)
+		req.Equal(seq, err)
+
+		var verr *ValidationError
+		req.ErrorAs(err, &verr)
+		req.Len(verr.Fields, 50)
+		req.Equal("[7].Age", verr.Fields[0].Field)
+		req.Equal("[4907].Age", verr.Fields[49].Field)
+	}
+
+	err := ValidateSliceParallel(people(7), 4)
+	req.NoError(err)
+
+	err = ValidateSliceParallel([]Person{}, 4)
+	req.NoError(err)
+}
+
+func BenchmarkValidateSlice(b *testing.B) {
+	ps := people(10000)
+	for i := 0; i < b.N; i++ {
+		_ = ValidateSlice(ps)
+	}
+}
+
+func BenchmarkValidateSliceParallel(b *testing.B) {
+	ps := people(10000)
+	for i := 0; i < b.N; i++ {
+		_ = ValidateSliceParallel(ps, 0)
+	}
+}
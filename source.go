@@ -0,0 +1,45 @@
+package validate
+
+// Source indicates where the value of a [Required] comes from.
+type Source uint8
+
+const (
+	// SourceNone indicates that there is no value.
+	SourceNone Source = iota
+	// SourceJSON indicates that the value has been unmarshalled from JSON.
+	SourceJSON
+	// SourceDefault indicates that the value is a default one.
+	SourceDefault
+	// SourceManual indicates that the value has been set programmatically.
+	SourceManual
+	// SourceSQL indicates that the value has been scanned from a database.
+	SourceSQL
+	// SourceCBOR indicates that the value has been unmarshalled from CBOR.
+	SourceCBOR
+)
+
+// sourced is implemented by types tracking the source of their values.
+type sourced interface {
+	Source() Source
+	setSource(Source)
+}
+
+var _ sourced = (*Required[int])(nil)
+
+func (s Source) String() string {
+	switch s {
+	case SourceNone:
+		return "none"
+	case SourceJSON:
+		return "json"
+	case SourceDefault:
+		return "default"
+	case SourceManual:
+		return "manual"
+	case SourceSQL:
+		return "sql"
+	case SourceCBOR:
+		return "cbor"
+	}
+	return "unknown"
+}
@@ -0,0 +1,73 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// ParseSourceKeys is like [ParseBytes] but also returns the JSON keys the values of the fields have been decoded from.
+func ParseSourceKeys(b []byte, obj interface{}) (keys map[string]string, err error) {
+	return defaultValidator.ParseSourceKeys(b, obj)
+}
+
+// ParseSourceKeys is like [Validator.ParseBytes] but also returns the JSON keys the values of the fields
+// of type [Required] have been decoded from, keyed by the paths of the fields in errors, e.g. `Address.Street`.
+// The keys may differ from the names given by the struct tags in case or be alternative names given
+// by the `jsonalt` tag. The keys of nested structures are recorded too. The keys are returned along with
+// the validation errors, but not if the JSON expression is malformed.
+func (vd *Validator) ParseSourceKeys(b []byte, obj interface{}) (keys map[string]string, err error) {
+	v, err := structPointer(obj)
+	if err != nil {
+		return nil, err
+	}
+	keys = make(map[string]string)
+	if err := vd.recordKeys(keys, v.Type(), b, ""); err != nil {
+		return nil, err
+	}
+	return keys, vd.parseBytes(b, obj)
+}
+
+// recordKeys records in keys the keys of the members of the JSON object b decoded into the fields
+// of the structure type t at the path, descending into nested objects.
+func (vd *Validator) recordKeys(keys map[string]string, t reflect.Type, b []byte, path string) error {
+	fields := jsonFields(t)
+	recorded := make(map[string]bool)
+	record := func(f jsonField, key string, value json.RawMessage) error {
+		recorded[f.name] = true
+		sf := t.FieldByIndex(f.index)
+		fpath := fieldPath(path, vd.fieldName(sf))
+		ft := sf.Type
+		if isRequiredField(ft) {
+			keys[fpath] = key
+			ft = reflect.New(indirectType(ft)).Interface().(RequiredIface).RequiredType()
+		}
+		ft = indirectType(ft)
+		if ft.Kind() == reflect.Struct && bytes.HasPrefix(bytes.TrimSpace(value), []byte("{")) {
+			return vd.recordKeys(keys, ft, value, fpath)
+		}
+		return nil
+	}
+	type member struct {
+		key   string
+		value json.RawMessage
+	}
+	var aliased []member
+	if err := objectMembers(b, func(key string, value json.RawMessage) error {
+		if f, ok := matchField(fields, key); ok {
+			return record(f, key, value)
+		}
+		aliased = append(aliased, member{key, value})
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, m := range aliased {
+		if f, ok := matchAlias(fields, m.key); ok && !recorded[f.name] {
+			if err := record(f, m.key, m.value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Registration struct {
+	User    Required[string]   `json:"user_name" jsonalt:"login"`
+	Email   Required[string]   `json:"email"`
+	Address Required[*Address] `json:"address"`
+	Billing Address            `json:"billing"`
+}
+
+func TestParseSourceKeys(t *testing.T) {
+	req := require.New(t)
+
+	var r Registration
+	keys, err := ParseSourceKeys([]byte(`{"user_name":"saoirse","EMAIL":"saoirse@example.com",
+		"address":{"street":"Main","Zip":"1"},"billing":{"street":"Main","zip":"1"}}`), &r)
+	req.NoError(err)
+	req.Equal(map[string]string{
+		"User":           "user_name",
+		"Email":          "EMAIL",
+		"Address":        "address",
+		"Address.Street": "street",
+		"Address.Zip":    "Zip",
+		"Billing.Street": "street",
+		"Billing.Zip":    "zip",
+	}, keys)
+	req.Equal("saoirse@example.com", r.Email.Get())
+
+	// alternative names are recorded as sent, and the keys are returned along with the validation errors
+	r = Registration{}
+	keys, err = ParseSourceKeys([]byte(`{"login":"saoirse","email":"saoirse@example.com","address":null,"billing":{}}`), &r)
+	req.Equal("field 'Billing.Street' in 'validate.Registration' is required\n"+
+		"field 'Billing.Zip' in 'validate.Registration' is required", err.Error())
+	req.Equal("saoirse", r.User.Get())
+	req.Equal(map[string]string{"User": "login", "Email": "email", "Address": "address"}, keys)
+
+	// the paths follow the naming of the fields in errors
+	r = Registration{}
+	keys, err = New(WithLowerCamelNames()).ParseSourceKeys([]byte(`{"user_name":"saoirse","email":"saoirse@example.com",
+		"address":null,"billing":{"street":"Main","zip":"1"}}`), &r)
+	req.NoError(err)
+	req.Equal("user_name", keys["user_name"])
+	req.Equal("street", keys["billing.street"])
+
+	keys, err = ParseSourceKeys([]byte(`{"user_name":`), &r)
+	req.Error(err)
+	req.Nil(keys)
+}
+
+func TestParseSourceKeysPointer(t *testing.T) {
+	req := require.New(t)
+
+	var p Patch
+	keys, err := ParseSourceKeys([]byte(`{"COUNT":1,"note":"x"}`), &p)
+	req.NoError(err)
+	req.Equal(map[string]string{"Count": "COUNT", "Note": "note"}, keys)
+	req.Equal(1, p.Count.Get())
+}
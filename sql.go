@@ -0,0 +1,25 @@
+package validate
+
+import "database/sql"
+
+var _ sql.Scanner = (*Required[int])(nil)
+
+// Scan implements [sql.Scanner] so that the type can be the destination of a database query,
+// e.g. in [sql.Rows.Scan] or sqlx's StructScan.
+// A NULL column value leaves the instance without a value so that a subsequent [Struct] reports it.
+// A frozen instance is left intact and [ErrFrozen] is returned.
+func (r *Required[T]) Scan(src interface{}) error {
+	if r.frozen {
+		return ErrFrozen
+	}
+	var n sql.Null[T]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	r.value, r.valid = n.V, n.Valid
+	r.source = SourceNone
+	if n.Valid {
+		r.source = SourceSQL
+	}
+	return nil
+}
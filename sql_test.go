@@ -0,0 +1,98 @@
+package validate
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type UserRow struct {
+	ID        Required[int64]     `db:"id"`
+	Email     Required[string]    `db:"email"`
+	CreatedAt Required[time.Time] `db:"created_at"`
+}
+
+// mockRows mimics the rows of a database driver yielding driver values.
+type mockRows struct {
+	columns []string
+	rows    [][]interface{}
+	i       int
+}
+
+func (r *mockRows) Next() bool {
+	r.i++
+	return r.i <= len(r.rows)
+}
+
+func (r *mockRows) Scan(dest ...interface{}) error {
+	for i, d := range dest {
+		if err := d.(sql.Scanner).Scan(r.rows[r.i-1][i]); err != nil {
+			return fmt.Errorf("column %s: %w", r.columns[i], err)
+		}
+	}
+	return nil
+}
+
+// structScan maps the columns to the fields by their `db` tags the way sqlx's StructScan does.
+func structScan(rows *mockRows, dest interface{}) error {
+	v := reflect.ValueOf(dest).Elem()
+	ptrs := make([]interface{}, len(rows.columns))
+	for i, col := range rows.columns {
+		for j := 0; j < v.NumField(); j++ {
+			if v.Type().Field(j).Tag.Get("db") == col {
+				ptrs[i] = v.Field(j).Addr().Interface()
+			}
+		}
+	}
+	return rows.Scan(ptrs...)
+}
+
+func TestScan(t *testing.T) {
+	req := require.New(t)
+
+	created := time.Date(2024, 9, 4, 12, 0, 0, 0, time.UTC)
+	rows := &mockRows{
+		columns: []string{"id", "email", "created_at"},
+		rows: [][]interface{}{
+			{int64(1), []byte("saoirse@example.com"), created},
+			{int64(2), nil, created},
+		},
+	}
+
+	var users []UserRow
+	for rows.Next() {
+		var u UserRow
+		req.NoError(structScan(rows, &u))
+		users = append(users, u)
+	}
+	req.Len(users, 2)
+
+	req.NoError(Struct(&users[0]))
+	req.Equal(int64(1), users[0].ID.Value())
+	req.Equal("saoirse@example.com", users[0].Email.Value())
+	req.Equal(created, users[0].CreatedAt.Value())
+
+	err := Struct(&users[1])
+	req.Equal("field 'Email' in 'validate.UserRow' is required", err.Error())
+}
+
+func TestScanConversionError(t *testing.T) {
+	req := require.New(t)
+
+	var r Required[int]
+	err := r.Scan("not a number")
+	req.Error(err)
+	req.False(r.HasValue())
+
+	err = r.Scan(int64(42))
+	req.NoError(err)
+	req.Equal(42, r.Value())
+
+	err = r.Scan(nil)
+	req.NoError(err)
+	req.False(r.HasValue())
+}
@@ -0,0 +1,29 @@
+package validate
+
+import (
+	"reflect"
+	"sync"
+)
+
+// structValidators maps structure types to the functions validating them.
+var structValidators sync.Map
+
+// RegisterStructValidator registers a function checking cross-field invariants of the structure type T,
+// useful for types which cannot be modified. Whenever [Struct] validates a T, including a nested one,
+// the function runs after the fields have been checked, and its error is listed in the [ValidationError]
+// after the errors of the fields; errors of nested structures are prefixed with their paths. A later registration
+// for the same type replaces the earlier one. [FastValidatable] implementations are dispatched to
+// without running the registered functions.
+//
+// RegisterStructValidator is safe for concurrent use.
+func RegisterStructValidator[T any](fn func(*T) error) {
+	structValidators.Store(reflect.TypeFor[T](), func(x interface{}) error { return fn(x.(*T)) })
+}
+
+func lookupStructValidator(t reflect.Type) (func(interface{}) error, bool) {
+	fn, ok := structValidators.Load(t)
+	if !ok {
+		return nil, false
+	}
+	return fn.(func(interface{}) error), true
+}
@@ -0,0 +1,77 @@
+package validate
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errNegativeAge = errors.New("age must not be negative")
+
+func checkPersonAge(p *Person) error {
+	if p.Age.HasValue() && p.Age.Get() < 0 {
+		return errNegativeAge
+	}
+	return nil
+}
+
+func TestRegisterStructValidator(t *testing.T) {
+	req := require.New(t)
+
+	RegisterStructValidator(checkPersonAge)
+	defer structValidators.Delete(reflect.TypeFor[Person]())
+
+	p := Person{Name: NewRequired("Saoirse"), Age: NewRequired(25)}
+	req.NoError(Struct(&p))
+
+	p.Age.Set(-1)
+	err := Struct(&p)
+	req.ErrorIs(err, errNegativeAge)
+	req.Equal("age must not be negative", err.Error())
+
+	p = Person{Age: NewRequired(-1)}
+	err = Struct(&p)
+	req.ErrorIs(err, errNegativeAge)
+	req.ErrorIs(err, ErrRequired)
+	verr, ok := err.(*ValidationError)
+	req.True(ok)
+	req.Len(verr.Fields, 2)
+	req.Equal("", verr.Fields[1].Field)
+	req.Equal(errNegativeAge, verr.Fields[1].Err)
+	req.Equal("field 'Name' in 'validate.Person' is required\nage must not be negative", err.Error())
+
+	e := Envelope[Person]{Data: NewRequired(Person{Name: NewRequired("Saoirse"), Age: NewRequired(-1)}), Meta: NewRequired("v1")}
+	err = Struct(&e)
+	req.ErrorIs(err, errNegativeAge)
+	req.Equal("Data: age must not be negative", err.Error())
+	req.Equal("/data", err.(*ValidationError).Fields[0].Pointer)
+
+	ps := []Person{{Name: NewRequired("a"), Age: NewRequired(1)}, {Name: NewRequired("b"), Age: NewRequired(-2)}}
+	err = ValidateSlice(ps)
+	req.ErrorIs(err, errNegativeAge)
+	req.Equal("[1]: age must not be negative", err.Error())
+	_, ok = err.(*ValidationError)
+	req.True(ok)
+}
+
+func TestRegisterStructValidatorConcurrent(t *testing.T) {
+	defer structValidators.Delete(reflect.TypeFor[Person]())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterStructValidator(checkPersonAge)
+		}()
+		go func() {
+			defer wg.Done()
+			p := Person{Name: NewRequired("Saoirse"), Age: NewRequired(-1)}
+			_ = Struct(&p)
+		}()
+	}
+	wg.Wait()
+}
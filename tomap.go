@@ -0,0 +1,18 @@
+package validate
+
+// ToMap returns the values of the fields of type [Required] of the structure obj points to which have a value,
+// keyed by the JSON names of the fields. Fields without a value are omitted, and so are fields tagged `json:"-"`.
+// The values are not copied deeply. ToMap returns nil if obj is not a pointer to a structure.
+func ToMap(obj interface{}) map[string]interface{} {
+	fields := Fields(obj)
+	if fields == nil {
+		return nil
+	}
+	m := make(map[string]interface{})
+	for name, x := range fields {
+		if x.HasValue() {
+			m[name] = x.Value()
+		}
+	}
+	return m
+}
@@ -0,0 +1,34 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToMap(t *testing.T) {
+	req := require.New(t)
+
+	var p Person
+	req.Empty(ToMap(&p))
+	p.Name.Set("Saoirse")
+	req.Equal(map[string]interface{}{"name": "Saoirse"}, ToMap(&p))
+	p.Age.Set(25)
+	req.Equal(map[string]interface{}{"name": "Saoirse", "age": 25}, ToMap(&p))
+
+	d := Derived{Base: &Base{}}
+	d.ID.Set(1)
+	d.Name.Set("Saoirse")
+	req.Equal(map[string]interface{}{"id": 1, "name": "Saoirse"}, ToMap(&d))
+	req.Equal(map[string]interface{}{}, ToMap(&Derived{}))
+
+	req.Nil(ToMap(p))
+}
+
+func TestToMapPointer(t *testing.T) {
+	req := require.New(t)
+
+	p := Patch{Count: &Required[int]{}}
+	p.Count.Set(3)
+	req.Equal(map[string]interface{}{"count": 3}, ToMap(&p))
+}
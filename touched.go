@@ -0,0 +1,42 @@
+package validate
+
+import "reflect"
+
+// toucher is implemented by required-like types tracking whether they have been unmarshalled into.
+type toucher interface {
+	Touched() bool
+}
+
+var (
+	_ toucher = (*Required[int])(nil)
+	_ toucher = (*RequiredOneOf[int, string])(nil)
+)
+
+// TouchedFields returns the names of the fields of the structure obj points to which have been unmarshalled into,
+// in the order of their declaration. Promoted fields are named by their own names.
+// It is meant for partial updates where only the fields sent by the client are to be written.
+func TouchedFields(obj interface{}) []string {
+	v, err := structPointer(obj)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, f := range reflect.VisibleFields(v.Type()) {
+		if !f.IsExported() {
+			continue
+		}
+		fv, err := v.FieldByIndexErr(f.Index)
+		if err != nil {
+			continue
+		}
+		if fv.Kind() != reflect.Pointer {
+			fv = fv.Addr()
+		} else if fv.IsNil() {
+			continue
+		}
+		if x, ok := fv.Interface().(toucher); ok && x.Touched() {
+			names = append(names, f.Name)
+		}
+	}
+	return names
+}
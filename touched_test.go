@@ -0,0 +1,39 @@
+package validate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTouched(t *testing.T) {
+	req := require.New(t)
+
+	var p Pagination
+	req.NoError(json.Unmarshal([]byte(`{"perPage":50,"query":"shoes"}`), &p))
+	req.NoError(FillDefaults(&p))
+	req.True(p.Page.HasValue())
+	req.False(p.Page.Touched())
+	req.True(p.PerPage.Touched())
+	req.Equal([]string{"PerPage", "Query"}, TouchedFields(&p))
+
+	var q Pagination
+	q.Page.Set(2)
+	req.False(q.Page.Touched())
+	req.Empty(TouchedFields(&q))
+	req.Nil(TouchedFields(q))
+
+	var c Comment
+	req.NoError(json.Unmarshal([]byte(`{"user":{"id":"u1"}}`), &c))
+	req.True(c.User.Touched())
+	req.Equal([]string{"User"}, TouchedFields(&c))
+}
+
+func TestTouchedPointer(t *testing.T) {
+	req := require.New(t)
+
+	var p Patch
+	req.NoError(json.Unmarshal([]byte(`{"count":1}`), &p))
+	req.Equal([]string{"Count"}, TouchedFields(&p))
+}
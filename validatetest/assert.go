@@ -0,0 +1,32 @@
+// Package validatetest provides test helpers for structures validated by the validate package.
+// It is separate so that production code does not depend on package testing.
+package validatetest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mailstepcz/validate"
+)
+
+// AssertValid validates the object with [validate.Struct] and fails the test immediately
+// if it is not valid, listing all the misbehaving fields.
+func AssertValid(tb testing.TB, obj interface{}) {
+	tb.Helper()
+	err := validate.Struct(obj)
+	if err == nil {
+		return
+	}
+	var verr *validate.ValidationError
+	if !errors.As(err, &verr) {
+		tb.Fatalf("%T is not valid: %v", obj, err)
+		return
+	}
+	var sb strings.Builder
+	for _, f := range verr.Fields {
+		sb.WriteString("\n\t")
+		sb.WriteString(f.Error())
+	}
+	tb.Fatalf("%T is not valid, %d field(s) misbehave:%s", obj, len(verr.Fields), sb.String())
+}
@@ -0,0 +1,48 @@
+package validatetest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mailstepcz/validate"
+	"github.com/stretchr/testify/require"
+)
+
+type person struct {
+	Name validate.Required[string] `json:"name"`
+	Age  validate.Required[int]    `json:"age"`
+}
+
+// fakeTB records the failures instead of stopping the test.
+type fakeTB struct {
+	testing.TB
+	helper   bool
+	failures []string
+}
+
+func (f *fakeTB) Helper() { f.helper = true }
+
+func (f *fakeTB) Fatalf(format string, args ...interface{}) {
+	f.failures = append(f.failures, fmt.Sprintf(format, args...))
+}
+
+func TestAssertValid(t *testing.T) {
+	req := require.New(t)
+
+	tb := &fakeTB{}
+	p := person{Name: validate.NewRequired("Saoirse"), Age: validate.NewRequired(25)}
+	AssertValid(tb, &p)
+	req.True(tb.helper)
+	req.Empty(tb.failures)
+
+	AssertValid(tb, &person{})
+	req.Equal([]string{"*validatetest.person is not valid, 2 field(s) misbehave:" +
+		"\n\tfield 'Name' in 'validatetest.person' is required" +
+		"\n\tfield 'Age' in 'validatetest.person' is required"}, tb.failures)
+
+	tb = &fakeTB{}
+	AssertValid(tb, p)
+	req.Equal([]string{"validatetest.person is not valid: bad type: validatetest.person"}, tb.failures)
+
+	AssertValid(t, &p)
+}
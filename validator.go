@@ -0,0 +1,845 @@
+package validate
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unsafe"
+)
+
+// Validator validates structures according to the options it has been created with.
+// The zero value is a validator with the default behaviour of [Struct].
+type Validator struct {
+	configured       bool
+	skip             map[string]bool
+	emptyMissing     bool
+	blankMissing     bool
+	zeroMissing      bool
+	nilMissing       bool
+	unknownField     func(name string)
+	cache            *lru
+	typeName         func(reflect.Type) string
+	progress         func(processed int)
+	progressEvery    int
+	maxBodySize      int64
+	lastValue        bool
+	maxDepth         int
+	maxKeys          int
+	maxKeysNested    bool
+	typeLabel        string
+	nullWarning      func(field string)
+	deprecation      func(field string)
+	untaggedOptional bool
+	mandatory        map[string]bool
+	metrics          func(structType, field string)
+	useNumber        bool
+	tagName          string
+	lowerCamel       bool
+}
+
+// Option configures a [Validator].
+type Option func(*Validator)
+
+var defaultValidator = &Validator{}
+
+// New creates a new validator with the provided options.
+func New(opts ...Option) *Validator {
+	v := &Validator{configured: len(opts) > 0}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// WithSkipFields makes the validator ignore the named fields even if they are unset.
+// Nested fields are named by their paths, e.g. `Address.Zip`.
+func WithSkipFields(names ...string) Option {
+	return func(v *Validator) {
+		if v.skip == nil {
+			v.skip = make(map[string]bool, len(names))
+		}
+		for _, name := range names {
+			v.skip[name] = true
+		}
+	}
+}
+
+// WithRequiredFields makes the validator decide which fields of type [Required] must have values by the map
+// rather than by their types and tags: only the fields mapped to true are required. The fields are named
+// by their paths like in [WithSkipFields]. Values of fields which are present are checked as usual.
+func WithRequiredFields(fields map[string]bool) Option {
+	return func(v *Validator) {
+		v.mandatory = fields
+	}
+}
+
+// WithEmptyStringAsMissing makes the validator treat fields of string underlying types
+// holding an empty string as if they had no value.
+func WithEmptyStringAsMissing() Option {
+	return func(v *Validator) {
+		v.emptyMissing = true
+	}
+}
+
+// WithBlankStringAsMissing makes the validator treat fields of string underlying types
+// holding an empty string or only whitespace as if they had no value.
+func WithBlankStringAsMissing() Option {
+	return func(v *Validator) {
+		v.blankMissing = true
+	}
+}
+
+// WithZeroAsMissing makes the validator treat fields holding the zero value of their underlying types
+// as if they had no value. Types implementing [ZeroChecker] decide for themselves what is zero.
+func WithZeroAsMissing() Option {
+	return func(v *Validator) {
+		v.zeroMissing = true
+	}
+}
+
+// WithNilElementsAsMissing makes the validator report nil pointers among the elements of slices and arrays
+// and the values of maps as missing. By default they are skipped.
+func WithNilElementsAsMissing() Option {
+	return func(v *Validator) {
+		v.nilMissing = true
+	}
+}
+
+// WithUnknownFieldCallback makes [Validator.Parse] call fn with the key of each member of the parsed JSON object
+// which does not correspond to any field of the structure. Parsing does not fail on such members.
+// Only the members of the top-level object are reported; the objects of nested structures are decoded
+// by encoding/json, which ignores their unknown members.
+func WithUnknownFieldCallback(fn func(name string)) Option {
+	return func(v *Validator) {
+		v.unknownField = fn
+	}
+}
+
+// WithCache makes [Validator.Parse] remember the payloads of up to size most recently validated structures
+// which have turned out valid, and skip the validation of identical payloads decoded into the same type.
+// The cache assumes that the payloads are decoded into zero-valued structures. It is safe for concurrent use.
+//
+// A cached payload is not validated again, so rules relative to the current time, such as `after:"now"`,
+// and validators registered with [RegisterStructValidator] after the payload has been cached do not apply to it.
+// The payloads are not cached if the validator reports fields to callbacks,
+// see [WithMetrics], [WithNullWarning] and [WithDeprecationWarning].
+func WithCache(size int) Option {
+	return func(v *Validator) {
+		v.cache = newLRU(size)
+	}
+}
+
+// WithTypeNames makes the validator name the validated structure types in errors using fn.
+// By default the short names returned by [reflect.Type.String] are used; see also [QualifiedTypeName].
+func WithTypeNames(fn func(reflect.Type) string) Option {
+	return func(v *Validator) {
+		v.typeName = fn
+	}
+}
+
+// WithTypeLabel makes the validator name anonymous structure types, e.g. `struct { Name Required[string] }`,
+// by the label in errors instead of their type literals. Named types are named as usual.
+func WithTypeLabel(label string) Option {
+	return func(v *Validator) {
+		v.typeLabel = label
+	}
+}
+
+// WithNullWarning makes the validator call fn with the path of each mandatory field which has been unmarshalled
+// from an explicit JSON `null`, e.g. to log a deprecation warning. Such fields still pass the validation.
+func WithNullWarning(fn func(field string)) Option {
+	return func(v *Validator) {
+		v.nullWarning = fn
+	}
+}
+
+// WithDeprecationWarning makes the validator call fn with the path of each field of type [Deprecated]
+// which has a value, so that the clients still using the field can be told to migrate off it.
+func WithDeprecationWarning(fn func(field string)) Option {
+	return func(v *Validator) {
+		v.deprecation = fn
+	}
+}
+
+// WithUntaggedOptional makes [Validator.StructFor] treat the fields without a `required` tag
+// listing operations as optional. By default they are enforced for all operations.
+func WithUntaggedOptional() Option {
+	return func(v *Validator) {
+		v.untaggedOptional = true
+	}
+}
+
+// WithMetrics makes the validator call fn with the name of the validated structure type and the path of the field
+// for each required field which is missing, e.g. to count the omissions by field.
+func WithMetrics(fn func(structType, field string)) Option {
+	return func(v *Validator) {
+		v.metrics = fn
+	}
+}
+
+// WithTagName makes the validator name the fields in errors by the struct tag with the provided key,
+// e.g. `form`, rather than by their Go names, which remain in use for fields without the tag.
+// The paths given to [WithSkipFields] and [WithRequiredFields] are formed of the same names.
+func WithTagName(key string) Option {
+	return func(v *Validator) {
+		v.tagName = key
+	}
+}
+
+// WithLowerCamelNames makes the validator name the fields in errors by their JSON names,
+// or by their Go names converted to lowerCamelCase if they have none, e.g. `accountId` for `AccountID`
+// and `url` for `URL`. A tag set by [WithTagName] takes precedence.
+func WithLowerCamelNames() Option {
+	return func(v *Validator) {
+		v.lowerCamel = true
+	}
+}
+
+// QualifiedTypeName returns the name of the type qualified by the full import path of its package,
+// e.g. `github.com/mailstepcz/validate.Person`.
+func QualifiedTypeName(t reflect.Type) string {
+	if t.Name() != "" && t.PkgPath() != "" {
+		return t.PkgPath() + "." + t.Name()
+	}
+	return t.String()
+}
+
+// WithProgress makes [Validator.ParseStream] and [Validator.ParseNDJSON] call fn with the number of elements
+// processed so far after every n elements.
+func WithProgress(n int, fn func(processed int)) Option {
+	return func(v *Validator) {
+		v.progress = fn
+		v.progressEvery = max(n, 1)
+	}
+}
+
+// WithMaxBodySize sets the maximum size of request bodies accepted by [Validator.Bind].
+func WithMaxBodySize(n int64) Option {
+	return func(v *Validator) {
+		v.maxBodySize = n
+	}
+}
+
+// Struct validates the provided argument which must be a pointer to a structure.
+// Any fields whose type is [Required] are checked, including those of nested structures
+// reachable through fields, pointers, interfaces, slices, arrays, maps and values of [Required] fields,
+// nested in any combination, e.g. `Groups["a"][2].Name` for a field of type map[string][]Item.
+// A [Required] field holding a nil pointer to a slice or a map is considered to have no value,
+// and so is a nil field of a pointer type such as *Required[T].
+// Cycles of pointers, slices and maps are not followed; each structure on a cycle is checked once.
+// Fields whose type is [Forbidden] are checked that they have no value.
+// The constraints given by the tags (see `min`, `max`, `pattern`, `after` and `before`) are checked
+// on all present fields, whether required or not; ordinary fields are present if they are not zero.
+// The returned error is a [ValidationError] containing the errors emitted for all misbehaving fields.
+//
+// Validators created without options dispatch to [FastValidatable] implementations like [Struct] does,
+// unless the method is promoted from an embedded structure.
+//
+// Struct never panics; an ill-typed argument yields an error wrapping [ErrBadType].
+func (vd *Validator) Struct(x interface{}) error {
+	if f, ok := fastValidatable(x); ok && !vd.configured {
+		return f.ValidateRequired()
+	}
+	v, err := structPointer(x)
+	if err != nil {
+		return err
+	}
+	return vd.StructValue(v)
+}
+
+// fastTypes caches whether the ValidateRequired methods of structure types are their own.
+var fastTypes sync.Map // map[reflect.Type]*layout[bool]
+
+// fastValidatable returns x as a [FastValidatable] unless the structure x points to has the method
+// only by embedding another [FastValidatable], which would not check the fields of the embedding structure.
+// A structure both embedding one and declaring its own method is validated by reflection too.
+func fastValidatable(x interface{}) (FastValidatable, bool) {
+	f, ok := x.(FastValidatable)
+	if !ok {
+		return nil, false
+	}
+	t := reflect.TypeOf(x)
+	if t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct {
+		return f, true
+	}
+	own := cachedLayout(&fastTypes, t.Elem(), func(t reflect.Type) bool {
+		fastType := reflect.TypeFor[FastValidatable]()
+		for _, sf := range reflect.VisibleFields(t) {
+			if sf.Anonymous && (sf.Type.Implements(fastType) || reflect.PointerTo(sf.Type).Implements(fastType)) {
+				return false
+			}
+		}
+		return true
+	})
+	return f, own
+}
+
+// StructValue is like [Validator.Struct] but takes the structure, or a pointer to it, as a reflection value.
+// A structure which is not addressable is validated as a copy.
+func (vd *Validator) StructValue(v reflect.Value) error {
+	return vd.structValue(v, "")
+}
+
+// StructWithPrefix is like [Validator.Struct] but prefixes the paths of the fields in errors,
+// e.g. `Customer.Age` for the prefix `Customer`. The JSON pointers are not prefixed.
+// [FastValidatable] implementations are not dispatched to.
+func (vd *Validator) StructWithPrefix(x interface{}, prefix string) error {
+	v, err := structPointer(x)
+	if err != nil {
+		return err
+	}
+	return vd.structValue(v, prefix)
+}
+
+// StructReport is like [Validator.Struct] but also returns the paths of the required fields which are present,
+// in the order of their declaration. [FastValidatable] implementations are not dispatched to.
+func (vd *Validator) StructReport(x interface{}) (satisfied []string, err error) {
+	v, err := structPointer(x)
+	if err != nil {
+		return nil, err
+	}
+	w := walker{vd: vd, typ: vd.nameType(v.Type()), report: true}
+	w.structure(v, "", "")
+	return w.satisfied, w.err()
+}
+
+// FirstMissing returns the path of the first missing required field of the structure x points to,
+// in declaration order with nested structures visited depth first, and whether any field is missing.
+// Other errors are ignored and no error is built; an ill-typed argument yields false.
+func (vd *Validator) FirstMissing(x interface{}) (field string, ok bool) {
+	v, err := structPointer(x)
+	if err != nil {
+		return "", false
+	}
+	w := walker{vd: vd, typ: vd.nameType(v.Type()), first: true}
+	w.structure(v, "", "")
+	return w.missed, w.done
+}
+
+func (vd *Validator) structValue(v reflect.Value, prefix string) error {
+	if !v.IsValid() {
+		return fmt.Errorf("%w: invalid value", ErrBadType)
+	}
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return fmt.Errorf("%w: nil %s", ErrBadType, v.Type())
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %s", ErrBadType, v.Type())
+	}
+	if !v.CanAddr() {
+		v = addressable(v)
+	}
+	w := walker{vd: vd, typ: vd.nameType(v.Type()), prefix: prefix}
+	w.structure(v, "", "")
+	return w.err()
+}
+
+// structPointer returns the structure the argument points to.
+func structPointer(x interface{}) (reflect.Value, error) {
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Pointer {
+		return reflect.Value{}, fmt.Errorf("%w: %T", ErrBadType, x)
+	}
+	if v.IsNil() {
+		return reflect.Value{}, fmt.Errorf("%w: nil %T", ErrBadType, x)
+	}
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("%w: %T", ErrBadType, x)
+	}
+	return v, nil
+}
+
+// fieldName returns the name of the field in errors.
+func (vd *Validator) fieldName(f reflect.StructField) string {
+	if vd.tagName != "" {
+		if name, _, _ := strings.Cut(f.Tag.Get(vd.tagName), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	if vd.lowerCamel {
+		if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" && name != "-" {
+			return name
+		}
+		return lowerCamel(f.Name)
+	}
+	return f.Name
+}
+
+// lowerCamel converts a Go identifier to lowerCamelCase. Runs of capitals are taken for acronyms,
+// the last capital of a run followed by a lowercase letter starting a new word, e.g. `httpServer` for `HTTPServer`,
+// except for a plural `s` ending the word, e.g. `userIds` for `UserIDs`.
+func lowerCamel(name string) string {
+	rs := []rune(name)
+	out := make([]rune, len(rs))
+	for i, r := range rs {
+		if i > 0 && unicode.IsUpper(r) && (!unicode.IsUpper(rs[i-1]) || i+1 < len(rs) && unicode.IsLower(rs[i+1]) && !pluralAcronym(rs, i+1)) {
+			// the first letter of a word
+			out[i] = r
+			continue
+		}
+		out[i] = unicode.ToLower(r)
+	}
+	return string(out)
+}
+
+// pluralAcronym reports whether rs[i] is an `s` ending the word after a run of capitals.
+func pluralAcronym(rs []rune, i int) bool {
+	return rs[i] == 's' && (i+1 == len(rs) || !unicode.IsLower(rs[i+1]))
+}
+
+func (vd *Validator) nameType(t reflect.Type) string {
+	if vd.typeLabel != "" && t.Name() == "" {
+		return vd.typeLabel
+	}
+	if vd.typeName != nil {
+		return vd.typeName(t)
+	}
+	return t.String()
+}
+
+// walker traverses a value and collects the errors of its misbehaving fields.
+type walker struct {
+	vd   *Validator
+	typ  string
+	errs []*FieldError
+	// prefix is prepended to the paths of the fields in errors but not in the lookups of the options
+	prefix string
+	// satisfied collects the paths of the present mandatory fields if report is set
+	report    bool
+	satisfied []string
+	// failed holds the errors of the fields which have failed to be assigned, reported in place of validating them
+	failed map[string]error
+	// visiting holds the structures, slices and maps being traversed so that cycles are not followed
+	visiting []visit
+	// op is the operation validated by [Validator.StructFor]
+	op string
+	// first stops the traversal at the first missing field, which is recorded in missed
+	first  bool
+	missed string
+	done   bool
+}
+
+// visit identifies a structure, a slice or a map being traversed by the walker.
+type visit struct {
+	p   unsafe.Pointer
+	t   reflect.Type
+	len int
+}
+
+// enter records that the walker descends into k, returning false if it is already doing so,
+// that is, if k has been reached through a cycle. The walker leaves k by calling [walker.leave].
+func (w *walker) enter(k visit) bool {
+	for _, v := range w.visiting {
+		if v == k {
+			return false
+		}
+	}
+	w.visiting = append(w.visiting, k)
+	return true
+}
+
+// leave records that the walker has finished the value it has last entered.
+func (w *walker) leave() {
+	w.visiting = w.visiting[:len(w.visiting)-1]
+}
+
+func (w *walker) fail(path, ptr string, err error) {
+	path = w.prefixed(path)
+	if err == ErrRequired && w.vd.metrics != nil {
+		w.vd.metrics(w.typ, path)
+	}
+	if w.first {
+		if err == ErrRequired {
+			w.missed, w.done = path, true
+		}
+		return
+	}
+	w.errs = append(w.errs, &FieldError{Type: w.typ, Field: path, Pointer: ptr, Err: err})
+}
+
+// prefixed returns the path of a field in errors.
+func (w *walker) prefixed(path string) string {
+	switch {
+	case w.prefix == "":
+		return path
+	case path == "":
+		return w.prefix
+	}
+	return w.prefix + "." + path
+}
+
+func (w *walker) err() error {
+	return fieldErrors(w.errs)
+}
+
+// structure checks the fields of the addressable structure v and runs the validator registered for its type
+// unless only the first missing field is looked for. A structure reached again through a cycle of pointers is skipped.
+func (w *walker) structure(v reflect.Value, path, ptr string) {
+	k := visit{v.Addr().UnsafePointer(), v.Type(), 0}
+	if !w.enter(k) {
+		return
+	}
+	defer w.leave()
+	w.fields(v, path, ptr)
+	if w.first {
+		return
+	}
+	if fn, ok := lookupStructValidator(v.Type()); ok {
+		if err := fn(v.Addr().Interface()); err != nil {
+			w.errs = append(w.errs, &FieldError{Type: w.typ, Field: w.prefixed(path), Pointer: ptr, Err: err, structure: true})
+		}
+	}
+}
+
+// fields checks the fields of the addressable structure v, whose layout is computed once per type.
+func (w *walker) fields(v reflect.Value, path, ptr string) {
+	for _, f := range walkedFields(v.Type()) {
+		if w.done {
+			return
+		}
+		fpath, fptr := fieldPath(path, w.vd.fieldName(f.StructField)), ptr+"/"+f.token
+		switch f.kind {
+		case forbiddenField:
+			if !f.IsExported() || w.vd.skip[fpath] {
+				continue
+			}
+			fv, err := v.FieldByIndexErr(f.Index)
+			if err != nil {
+				continue
+			}
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			if fv.Addr().Interface().(forbiddenIface).HasValue() {
+				w.fail(fpath, fptr, ErrForbidden)
+			}
+		case presenceField:
+			if err, ok := w.failed[fpath]; ok {
+				w.fail(fpath, fptr, err)
+				continue
+			}
+			if w.vd.skip[fpath] {
+				continue
+			}
+			if !f.IsExported() {
+				w.fail(fpath, fptr, errUnexported)
+				continue
+			}
+			mandatory, err := w.mandatory(v, f.StructField)
+			if err != nil {
+				w.fail(fpath, fptr, err)
+				continue
+			}
+			if w.vd.mandatory != nil {
+				mandatory = w.vd.mandatory[fpath]
+			}
+			if f.deprecated {
+				mandatory = false
+			}
+			fv, err := v.FieldByIndexErr(f.Index)
+			if err != nil {
+				// the field is promoted through a nil embedded pointer
+				if mandatory {
+					w.fail(fpath, fptr, ErrRequired)
+				}
+				continue
+			}
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					if mandatory {
+						w.fail(fpath, fptr, ErrRequired)
+					}
+					continue
+				}
+				fv = fv.Elem()
+			}
+			w.required(fv.Addr().Interface(), f.Tag, fpath, fptr, mandatory)
+		case promotedField:
+			// promoted fields are visited on their own
+		case plainField:
+			if f.rules && !w.vd.skip[fpath] {
+				if fv, err := v.FieldByIndexErr(f.Index); err == nil {
+					if err := optionalRules(f.Tag, fv); err != nil {
+						w.fail(fpath, fptr, err)
+						continue
+					}
+				}
+			}
+			if !f.descends {
+				continue
+			}
+			if fv, err := v.FieldByIndexErr(f.Index); err == nil {
+				w.value(fv, fpath, fptr)
+			}
+		}
+	}
+}
+
+// optionalRules checks the value of a field of an ordinary type against the constraints given by its tag.
+// A zero value or a nil pointer counts as absent and is not checked.
+func optionalRules(tag reflect.StructTag, v reflect.Value) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	} else if v.IsZero() {
+		return nil
+	}
+	return checkRules(tag, v)
+}
+
+// required checks the presence of a value in x, which is a [RequiredIface] or a [RequiredContainer],
+// if it is mandatory, checks the value against the constraints given by the tag, and descends into it.
+func (w *walker) required(x interface{}, tag reflect.StructTag, path, ptr string, mandatory bool) {
+	if present, ok := scalarPresence(x); ok && w.scalar(tag) {
+		switch {
+		case !present && mandatory:
+			w.fail(path, ptr, ErrRequired)
+		case present && mandatory && w.report:
+			w.satisfied = append(w.satisfied, path)
+		}
+		return
+	}
+	var inner reflect.Value
+	_, deprecated := x.(deprecatedIface)
+	if deprecated {
+		mandatory = false
+	}
+	switch x := x.(type) {
+	case RequiredIface:
+		if w.missing(x) {
+			if mandatory {
+				w.fail(path, ptr, ErrRequired)
+			}
+			return
+		}
+		if deprecated && w.vd.deprecation != nil {
+			w.vd.deprecation(path)
+		}
+		if w.report && mandatory {
+			w.satisfied = append(w.satisfied, path)
+		}
+		if n, ok := x.(nuller); ok && mandatory && w.vd.nullWarning != nil && n.WasNull() {
+			w.vd.nullWarning(path)
+		}
+		if err := checkRules(tag, x.SettableValue()); err != nil {
+			w.fail(path, ptr, err)
+			return
+		}
+		if x.IsStruct() || descends(x.RequiredType()) {
+			inner = x.SettableValue()
+		}
+	case RequiredContainer:
+		if !x.Present() {
+			if mandatory {
+				w.fail(path, ptr, ErrRequired)
+			}
+			return
+		}
+		if w.report && mandatory {
+			w.satisfied = append(w.satisfied, path)
+		}
+		inner = reflect.ValueOf(x.Inner())
+	}
+	if c, ok := x.(Checker); ok {
+		if err := c.Check(); err != nil {
+			w.fail(path, ptr, invalid(err))
+			return
+		}
+	}
+	if inner.IsValid() && descends(inner.Type()) {
+		w.value(inner, path, ptr)
+	}
+}
+
+// missing tells whether x is to be considered as having no value.
+// A nil pointer to a slice or a map counts as no value.
+func (w *walker) missing(x RequiredIface) bool {
+	switch {
+	case !x.HasValue() || isNilContainerPointer(x):
+		return true
+	case w.vd.zeroMissing && isZero(x):
+		return true
+	case w.vd.emptyMissing && x.Kind() == reflect.String && isZero(x):
+		return true
+	case w.vd.blankMissing && x.Kind() == reflect.String:
+		return strings.TrimFunc(x.SettableValue().String(), unicode.IsSpace) == ""
+	}
+	return false
+}
+
+// value descends into v looking for nested structures.
+func (w *walker) value(v reflect.Value, path, ptr string) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			w.value(v.Elem(), path, ptr)
+		}
+	case reflect.Struct:
+		if !v.CanAddr() {
+			v = addressable(v)
+		}
+		switch x := v.Addr().Interface().(type) {
+		case forbiddenIface:
+			if x.HasValue() {
+				w.fail(path, ptr, ErrForbidden)
+			}
+		case RequiredIface, RequiredContainer:
+			w.required(x, "", path, ptr, true)
+		default:
+			w.structure(v, path, ptr)
+		}
+	case reflect.Slice, reflect.Array:
+		if !descends(v.Type().Elem()) {
+			return
+		}
+		if v.Kind() == reflect.Slice && v.Len() > 0 {
+			k := visit{v.UnsafePointer(), v.Type(), v.Len()}
+			if !w.enter(k) {
+				return
+			}
+			defer w.leave()
+		}
+		for i := 0; i < v.Len(); i++ {
+			w.element(v.Index(i), path+"["+strconv.Itoa(i)+"]", ptr+"/"+strconv.Itoa(i))
+		}
+	case reflect.Map:
+		if !descends(v.Type().Elem()) || v.IsNil() {
+			return
+		}
+		k := visit{v.UnsafePointer(), v.Type(), 0}
+		if !w.enter(k) {
+			return
+		}
+		defer w.leave()
+		for _, k := range sortedKeys(v) {
+			key := fmt.Sprint(k.Interface())
+			index := key
+			if k.Kind() == reflect.String {
+				index = strconv.Quote(key)
+			}
+			w.element(v.MapIndex(k), path+"["+index+"]", ptr+"/"+pointerToken(key))
+		}
+	}
+}
+
+// invalid makes sure the error wraps [ErrInvalid].
+func invalid(err error) error {
+	if errors.Is(err, ErrInvalid) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrInvalid, err)
+}
+
+// element descends into an element of a container.
+func (w *walker) element(v reflect.Value, path, ptr string) {
+	if w.vd.nilMissing && v.Kind() == reflect.Pointer && v.IsNil() {
+		w.fail(path, ptr, ErrRequired)
+		return
+	}
+	w.value(v, path, ptr)
+}
+
+// nuller is implemented by required-like types tracking whether they have been unmarshalled from `null`.
+type nuller interface {
+	WasNull() bool
+}
+
+// isPresenceType tells whether the presence of values of type t is tracked,
+// that is, whether t is a required-like type other than [Forbidden] or a [RequiredContainer].
+func isPresenceType(t reflect.Type) bool {
+	return isRequiredType(t) || reflect.PointerTo(t).Implements(requiredContainerType)
+}
+
+// descends tells whether values of type t may contain structures to be validated.
+// Interfaces may hold anything.
+func descends(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct, reflect.Interface:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return descends(t.Elem())
+	}
+	return false
+}
+
+// isNilContainerPointer tells whether the value of x is a nil pointer to a slice or a map.
+func isNilContainerPointer(x RequiredIface) bool {
+	t := x.RequiredType()
+	if t.Kind() != reflect.Pointer {
+		return false
+	}
+	if k := t.Elem().Kind(); k != reflect.Slice && k != reflect.Map {
+		return false
+	}
+	return x.SettableValue().IsNil()
+}
+
+func isZero(x RequiredIface) bool {
+	if z, ok := x.(ZeroChecker); ok {
+		return z.IsZeroValue()
+	}
+	return x.SettableValue().IsZero()
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
+
+// addressable returns an addressable copy of v.
+func addressable(v reflect.Value) reflect.Value {
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
+}
+
+func fieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// jsonName returns the JSON key of the field.
+func jsonName(f reflect.StructField) string {
+	if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return f.Name
+}
+
+// sortedKeys returns the keys of the map v in a deterministic order.
+func sortedKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		switch a.Kind() {
+		case reflect.String:
+			return cmp.Compare(a.String(), b.String())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return cmp.Compare(a.Int(), b.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return cmp.Compare(a.Uint(), b.Uint())
+		case reflect.Float32, reflect.Float64:
+			return cmp.Compare(a.Float(), b.Float())
+		}
+		return cmp.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+	})
+	return keys
+}
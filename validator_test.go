@@ -0,0 +1,789 @@
+package validate
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Contact struct {
+	Name  Required[string] `json:"name"`
+	Email Required[string] `json:"email"`
+	Phone Required[string] `json:"phone"`
+}
+
+func TestWithSkipFields(t *testing.T) {
+	req := require.New(t)
+
+	var c Contact
+	err := json.Unmarshal([]byte(`{"name":"Saoirse"}`), &c)
+	req.NoError(err)
+
+	err = New().Struct(&c)
+	req.Equal("field 'Email' in 'validate.Contact' is required\nfield 'Phone' in 'validate.Contact' is required", err.Error())
+
+	err = New(WithSkipFields("Phone")).Struct(&c)
+	req.Equal("field 'Email' in 'validate.Contact' is required", err.Error())
+
+	err = New(WithSkipFields("Email", "Phone")).Struct(&c)
+	req.NoError(err)
+}
+
+type Address struct {
+	Street Required[string] `json:"street"`
+	Zip    Required[string] `json:"zip"`
+}
+
+type Item struct {
+	SKU Required[string] `json:"sku"`
+	Qty int              `json:"qty"`
+}
+
+type Order struct {
+	ID       Required[string]  `json:"id"`
+	Address  Address           `json:"address"`
+	Billing  Required[Address] `json:"billing"`
+	Items    []Item            `json:"items"`
+	Extras   map[string]*Item  `json:"extras"`
+	Shipping *Address          `json:"shipping"`
+}
+
+type Catalog struct {
+	Groups   map[string][]Item             `json:"groups"`
+	Grid     [][]Item                      `json:"grid"`
+	Shelves  []map[string]Item             `json:"shelves"`
+	Bins     *map[string][]*Item           `json:"bins"`
+	Sections Required[map[string][2]*Item] `json:"sections"`
+}
+
+func TestNestedContainerPaths(t *testing.T) {
+	req := require.New(t)
+
+	var c Catalog
+	err := json.Unmarshal([]byte(`{
+		"groups": {"a": [{"sku":"1"}, {"sku":"2"}, {"qty":3}], "b": [{}]},
+		"grid": [[{"sku":"1"}], [{"sku":"2"}, {"qty":1}]],
+		"shelves": [{"top": {"sku":"1"}}, {"bottom": {}}],
+		"bins": {"x": [null, {"qty":1}]},
+		"sections": {"s": [{"sku":"1"}, {}]}
+	}`), &c)
+	req.NoError(err)
+
+	err = Struct(&c)
+	var verr *ValidationError
+	req.ErrorAs(err, &verr)
+	var paths, pointers []string
+	for _, ferr := range verr.Fields {
+		req.ErrorIs(ferr, ErrRequired)
+		paths = append(paths, ferr.Field)
+		pointers = append(pointers, ferr.Pointer)
+	}
+	req.Equal([]string{
+		`Groups["a"][2].SKU`,
+		`Groups["b"][0].SKU`,
+		`Grid[1][1].SKU`,
+		`Shelves[1]["bottom"].SKU`,
+		`Bins["x"][1].SKU`,
+		`Sections["s"][1].SKU`,
+	}, paths)
+	req.Equal([]string{
+		"/groups/a/2/sku",
+		"/groups/b/0/sku",
+		"/grid/1/1/sku",
+		"/shelves/1/bottom/sku",
+		"/bins/x/1/sku",
+		"/sections/s/1/sku",
+	}, pointers)
+}
+
+func TestFirstMissing(t *testing.T) {
+	req := require.New(t)
+
+	var c Contact
+	c.Name.Set("Saoirse")
+	field, ok := FirstMissing(&c)
+	req.True(ok)
+	req.Equal("Email", field)
+
+	c.Email.Set("saoirse@example.com")
+	field, ok = FirstMissing(&c)
+	req.True(ok)
+	req.Equal("Phone", field)
+
+	c.Phone.Set("+353 1 234 5678")
+	field, ok = FirstMissing(&c)
+	req.False(ok)
+	req.Empty(field)
+
+	var o Order
+	req.NoError(json.Unmarshal([]byte(`{"id":"1","address":{"street":"Main"},"billing":{"street":"Main","zip":"1"}}`), &o))
+	field, ok = FirstMissing(&o)
+	req.True(ok)
+	req.Equal("Address.Zip", field)
+
+	_, ok = FirstMissing(c)
+	req.False(ok)
+}
+
+func TestNestedFieldErrors(t *testing.T) {
+	req := require.New(t)
+
+	var o Order
+	err := json.Unmarshal([]byte(`{
+		"id": "o1",
+		"address": {"street": "Main"},
+		"billing": {"zip": "12345"},
+		"items": [{"sku": "a"}, {"sku": "b"}, {"qty": 1}],
+		"extras": {"gift/wrap": {"qty": 1}}
+	}`), &o)
+	req.NoError(err)
+
+	err = Struct(&o)
+	var verr *ValidationError
+	req.ErrorAs(err, &verr)
+
+	var (
+		fields   []string
+		pointers []string
+	)
+	for _, f := range verr.Fields {
+		req.Equal("validate.Order", f.Type)
+		req.ErrorIs(f, ErrRequired)
+		fields = append(fields, f.Field)
+		pointers = append(pointers, f.Pointer)
+	}
+	req.Equal([]string{"Address.Zip", "Billing.Street", "Items[2].SKU", `Extras["gift/wrap"].SKU`}, fields)
+	req.Equal([]string{"/address/zip", "/billing/street", "/items/2/sku", "/extras/gift~1wrap/sku"}, pointers)
+	req.Equal("field 'Address.Zip' in 'validate.Order' is required\n"+
+		"field 'Billing.Street' in 'validate.Order' is required\n"+
+		"field 'Items[2].SKU' in 'validate.Order' is required\n"+
+		"field 'Extras[\"gift/wrap\"].SKU' in 'validate.Order' is required", err.Error())
+}
+
+func TestTopLevelFieldPointer(t *testing.T) {
+	req := require.New(t)
+
+	var p Person
+	err := Struct(&p)
+	var verr *ValidationError
+	req.ErrorAs(err, &verr)
+	req.Len(verr.Fields, 2)
+	req.Equal("Name", verr.Fields[0].Field)
+	req.Equal("/name", verr.Fields[0].Pointer)
+	req.Equal("Age", verr.Fields[1].Field)
+	req.Equal("/age", verr.Fields[1].Pointer)
+}
+
+type Nickname string
+
+type Profile struct {
+	Name Required[string]   `json:"name"`
+	Nick Required[Nickname] `json:"nick"`
+	Age  Required[int]      `json:"age"`
+}
+
+func TestWithEmptyStringAsMissing(t *testing.T) {
+	req := require.New(t)
+
+	var p Profile
+	err := json.Unmarshal([]byte(`{"name":"","nick":"","age":0}`), &p)
+	req.NoError(err)
+
+	err = Struct(&p)
+	req.NoError(err)
+
+	err = New(WithEmptyStringAsMissing()).Struct(&p)
+	req.Equal("field 'Name' in 'validate.Profile' is required\nfield 'Nick' in 'validate.Profile' is required", err.Error())
+
+	err = json.Unmarshal([]byte(`{"name":"Saoirse","nick":"Sao"}`), &p)
+	req.NoError(err)
+
+	err = New(WithEmptyStringAsMissing()).Struct(&p)
+	req.NoError(err)
+}
+
+func TestWithBlankStringAsMissing(t *testing.T) {
+	req := require.New(t)
+
+	vd := New(WithBlankStringAsMissing())
+	for _, name := range []string{`"   "`, `"\t\n"`, `""`} {
+		var p Profile
+		req.NoError(json.Unmarshal([]byte(`{"name":`+name+`,"nick":"Sao","age":0}`), &p))
+		req.NoError(Struct(&p), name)
+		err := vd.Struct(&p)
+		req.ErrorIs(err, ErrRequired, name)
+		req.Equal("field 'Name' in 'validate.Profile' is required", err.Error(), name)
+	}
+
+	var p Profile
+	req.NoError(json.Unmarshal([]byte(`{"name":" Saoirse ","nick":"Sao","age":0}`), &p))
+	req.NoError(vd.Struct(&p))
+	req.Equal(" Saoirse ", p.Name.Get())
+}
+
+// Percentage considers values which round to 0.00 as zero.
+type Percentage struct {
+	Required[float64]
+}
+
+func (p *Percentage) IsZeroValue() bool { return math.Abs(p.value) < 0.005 }
+
+type Discount struct {
+	Code  Required[string] `json:"code"`
+	Uses  Required[int]    `json:"uses"`
+	Ratio Percentage       `json:"ratio"`
+}
+
+func TestWithZeroAsMissing(t *testing.T) {
+	req := require.New(t)
+
+	var d Discount
+	err := json.Unmarshal([]byte(`{"code":"","uses":0,"ratio":0.001}`), &d)
+	req.NoError(err)
+	req.True(d.Code.IsZeroValue())
+	req.True(d.Uses.IsZeroValue())
+	req.False(d.Ratio.Required.IsZeroValue())
+	req.True(d.Ratio.IsZeroValue())
+
+	req.NoError(Struct(&d))
+
+	err = New(WithZeroAsMissing()).Struct(&d)
+	req.Equal("field 'Code' in 'validate.Discount' is required\n"+
+		"field 'Uses' in 'validate.Discount' is required\n"+
+		"field 'Ratio' in 'validate.Discount' is required", err.Error())
+
+	err = New(WithEmptyStringAsMissing()).Struct(&d)
+	req.Equal("field 'Code' in 'validate.Discount' is required", err.Error())
+
+	err = json.Unmarshal([]byte(`{"code":"SUMMER","uses":3,"ratio":0.25}`), &d)
+	req.NoError(err)
+	req.NoError(New(WithZeroAsMissing()).Struct(&d))
+}
+
+type Section struct {
+	Host Required[string] `json:"host"`
+	Port Required[int]    `json:"port"`
+}
+
+type Config struct {
+	Sections map[string]*Section `json:"sections"`
+}
+
+func TestMapPointerValues(t *testing.T) {
+	req := require.New(t)
+
+	var c Config
+	err := json.Unmarshal([]byte(`{"sections":{"db":{"port":5432},"cache":null,"web":{"host":"localhost","port":80}}}`), &c)
+	req.NoError(err)
+	req.Nil(c.Sections["cache"])
+
+	err = Struct(&c)
+	req.Equal(`field 'Sections["db"].Host' in 'validate.Config' is required`, err.Error())
+
+	err = New(WithNilElementsAsMissing()).Struct(&c)
+	req.Equal(`field 'Sections["cache"]' in 'validate.Config' is required`+"\n"+
+		`field 'Sections["db"].Host' in 'validate.Config' is required`, err.Error())
+
+	var verr *ValidationError
+	req.ErrorAs(err, &verr)
+	req.Equal("/sections/cache", verr.Fields[0].Pointer)
+	req.Equal("/sections/db/host", verr.Fields[1].Pointer)
+}
+
+func TestStructValue(t *testing.T) {
+	req := require.New(t)
+
+	p := Person{Name: Required[string]{value: "Saoirse", valid: true}}
+
+	// addressable
+	err := StructValue(reflect.ValueOf(&p).Elem())
+	req.Equal("field 'Age' in 'validate.Person' is required", err.Error())
+	err = StructValue(reflect.ValueOf(&p))
+	req.Equal("field 'Age' in 'validate.Person' is required", err.Error())
+
+	// not addressable
+	err = StructValue(reflect.ValueOf(p))
+	req.Equal("field 'Age' in 'validate.Person' is required", err.Error())
+
+	p.Age.Set(25)
+	req.NoError(StructValue(reflect.ValueOf(p)))
+
+	req.ErrorIs(StructValue(reflect.Value{}), ErrBadType)
+	req.ErrorIs(StructValue(reflect.ValueOf(42)), ErrBadType)
+	req.ErrorIs(StructValue(reflect.ValueOf((*Person)(nil))), ErrBadType)
+}
+
+func TestWithTypeNames(t *testing.T) {
+	req := require.New(t)
+
+	var p Person
+	p.Name.Set("Saoirse")
+
+	err := Struct(&p)
+	req.Equal("field 'Age' in 'validate.Person' is required", err.Error())
+
+	err = New(WithTypeNames(QualifiedTypeName)).Struct(&p)
+	req.Equal("field 'Age' in 'github.com/mailstepcz/validate.Person' is required", err.Error())
+
+	err = New(WithTypeNames(func(t reflect.Type) string { return "person" })).Struct(&p)
+	req.Equal("field 'Age' in 'person' is required", err.Error())
+
+	req.Equal("[]validate.Person", QualifiedTypeName(reflect.TypeFor[[]Person]()))
+	req.Equal("int", QualifiedTypeName(reflect.TypeFor[int]()))
+}
+
+func TestAnonymousStruct(t *testing.T) {
+	req := require.New(t)
+
+	body := &struct {
+		Name  Required[string] `json:"name"`
+		Email Required[string] `json:"email"`
+	}{}
+	req.NoError(json.Unmarshal([]byte(`{"name":"Saoirse"}`), body))
+	err := Struct(body)
+	req.Equal(`field 'Email' in 'struct { Name validate.Required[string] "json:\"name\""; Email validate.Required[string] "json:\"email\"" }' is required`, err.Error())
+
+	err = New(WithTypeLabel("signup request")).Struct(body)
+	req.Equal("field 'Email' in 'signup request' is required", err.Error())
+
+	var p Person
+	err = New(WithTypeLabel("signup request")).Struct(&p)
+	req.Equal("field 'Name' in 'validate.Person' is required\nfield 'Age' in 'validate.Person' is required", err.Error())
+
+	err = ParseBytes([]byte(`{"name":"Saoirse","email":"saoirse@example.com"}`), body)
+	req.NoError(err)
+}
+
+// Maybe is a third-party optional type.
+type Maybe[T any] struct {
+	value *T
+}
+
+func (m *Maybe[T]) UnmarshalJSON(b []byte) error {
+	m.value = new(T)
+	return json.Unmarshal(b, m.value)
+}
+
+func (m *Maybe[T]) Present() bool { return m.value != nil }
+
+func (m *Maybe[T]) Inner() interface{} { return m.value }
+
+type Shipment struct {
+	ID   Required[string] `json:"id"`
+	From Maybe[Address]   `json:"from"`
+	To   Maybe[Address]   `json:"to"`
+}
+
+func TestRequiredContainer(t *testing.T) {
+	req := require.New(t)
+
+	var s Shipment
+	err := json.Unmarshal([]byte(`{"id":"s1","from":{"street":"Main","zip":"12345"},"to":{"street":"High"}}`), &s)
+	req.NoError(err)
+	err = Struct(&s)
+	req.Equal("field 'To.Zip' in 'validate.Shipment' is required", err.Error())
+
+	s = Shipment{}
+	err = json.Unmarshal([]byte(`{"id":"s1","from":{"street":"Main","zip":"12345"}}`), &s)
+	req.NoError(err)
+	err = Struct(&s)
+	req.Equal("field 'To' in 'validate.Shipment' is required", err.Error())
+}
+
+type Basket struct {
+	Items   *[]Item                    `json:"items"`
+	Extras  *map[string]Item           `json:"extras"`
+	Bundles Required[*[]Item]          `json:"bundles"`
+	Gifts   Required[*map[string]Item] `json:"gifts"`
+}
+
+func TestPointerToContainer(t *testing.T) {
+	req := require.New(t)
+
+	var b Basket
+	err := json.Unmarshal([]byte(`{
+		"items": [{"sku":"a"},{"qty":1}],
+		"extras": {"bag":{"qty":2}},
+		"bundles": [{"qty":3}],
+		"gifts": {"card":{"sku":"c"}}
+	}`), &b)
+	req.NoError(err)
+	err = Struct(&b)
+	req.Equal(`field 'Items[1].SKU' in 'validate.Basket' is required
+field 'Extras["bag"].SKU' in 'validate.Basket' is required
+field 'Bundles[0].SKU' in 'validate.Basket' is required`, err.Error())
+	verr := err.(*ValidationError)
+	req.Equal("/items/1/sku", verr.Fields[0].Pointer)
+	req.Equal("/extras/bag/sku", verr.Fields[1].Pointer)
+
+	b = Basket{}
+	err = json.Unmarshal([]byte(`{"items":null,"bundles":null,"gifts":null}`), &b)
+	req.NoError(err)
+	err = Struct(&b)
+	req.Equal(`field 'Bundles' in 'validate.Basket' is required
+field 'Gifts' in 'validate.Basket' is required`, err.Error())
+}
+
+func TestWithNullWarning(t *testing.T) {
+	req := require.New(t)
+
+	var warned []string
+	vd := New(WithNullWarning(func(field string) { warned = append(warned, field) }))
+
+	var o Order
+	err := json.Unmarshal([]byte(`{"id":null,"address":{"street":"Main","zip":null},"billing":{"street":"High","zip":"1"}}`), &o)
+	req.NoError(err)
+	req.True(o.ID.WasNull())
+	req.False(o.Billing.WasNull())
+	req.NoError(vd.Struct(&o))
+	req.Equal([]string{"ID", "Address.Zip"}, warned)
+
+	warned = nil
+	o = Order{}
+	err = json.Unmarshal([]byte(`{"id":"o1","address":{"street":"Main","zip":"12345"},"billing":{"street":"High","zip":"1"}}`), &o)
+	req.NoError(err)
+	req.NoError(vd.Struct(&o))
+	req.Empty(warned)
+	req.NoError(Struct(&o))
+}
+
+func TestStructWithPrefix(t *testing.T) {
+	req := require.New(t)
+
+	var p Person
+	p.Name.Set("Saoirse")
+	err := StructWithPrefix(&p, "customer")
+	req.Equal("field 'customer.Age' in 'validate.Person' is required", err.Error())
+	req.Equal("/age", err.(*ValidationError).Fields[0].Pointer)
+
+	var o Order
+	req.NoError(json.Unmarshal([]byte(`{"id":"o1","address":{"street":"Main"},"billing":{"zip":"1"}}`), &o))
+	err = StructWithPrefix(&o, "orders[3]")
+	req.Equal(`field 'orders[3].Address.Zip' in 'validate.Order' is required
+field 'orders[3].Billing.Street' in 'validate.Order' is required`, err.Error())
+
+	err = StructWithPrefix(&p, "")
+	req.Equal("field 'Age' in 'validate.Person' is required", err.Error())
+
+	fp := FastPerson{}
+	err = StructWithPrefix(&fp, "fast")
+	req.Equal(0, fp.calls)
+	req.Equal("field 'fast.Name' in 'validate.FastPerson' is required\nfield 'fast.Age' in 'validate.FastPerson' is required", err.Error())
+
+	p = Person{}
+	err = New(WithSkipFields("Age")).StructWithPrefix(&p, "customer")
+	req.Equal("field 'customer.Name' in 'validate.Person' is required", err.Error())
+}
+
+func TestStructConfigured(t *testing.T) {
+	req := require.New(t)
+
+	var c Contact
+	c.Name.Set("Saoirse")
+
+	req.NoError(StructConfigured(&c, map[string]bool{"Name": true}))
+	req.NoError(StructConfigured(&c, map[string]bool{"Name": true, "Phone": false}))
+	req.NoError(StructConfigured(&c, map[string]bool{}))
+
+	err := StructConfigured(&c, map[string]bool{"Name": true, "Phone": true})
+	req.Equal("field 'Phone' in 'validate.Contact' is required", err.Error())
+
+	var o Order
+	o.ID.Set("o1")
+	err = StructConfigured(&o, map[string]bool{"ID": true, "Address.Zip": true})
+	req.Equal("field 'Address.Zip' in 'validate.Order' is required", err.Error())
+
+	tk := newTicket()
+	tk.Status.Set("lost")
+	err = StructConfigured(&tk, map[string]bool{})
+	req.ErrorIs(err, ErrInvalid)
+}
+
+func TestWithMetrics(t *testing.T) {
+	req := require.New(t)
+
+	var recorded [][2]string
+	vd := New(WithMetrics(func(structType, field string) {
+		recorded = append(recorded, [2]string{structType, field})
+	}))
+
+	var c Contact
+	c.Email.Set("saoirse@example.com")
+	err := vd.Struct(&c)
+	req.Error(err)
+	req.Equal([][2]string{{"validate.Contact", "Name"}, {"validate.Contact", "Phone"}}, recorded)
+
+	recorded = nil
+	tk := newTicket()
+	tk.Status.Set("lost")
+	req.Error(vd.Struct(&tk))
+	req.Equal([][2]string{{"validate.Ticket", "Title"}}, recorded)
+}
+
+type Envelope[T any] struct {
+	Data Required[T]      `json:"data"`
+	Meta Required[string] `json:"meta"`
+}
+
+func TestGenericStruct(t *testing.T) {
+	req := require.New(t)
+
+	var ep Envelope[Person]
+	req.NoError(json.Unmarshal([]byte(`{"data":{"name":"Saoirse"},"meta":"v1"}`), &ep))
+	err := Struct(&ep)
+	req.Equal("field 'Data.Age' in 'validate.Envelope[github.com/mailstepcz/validate.Person]' is required", err.Error())
+	req.Equal("/data/age", err.(*ValidationError).Fields[0].Pointer)
+
+	var eps Envelope[[]Person]
+	req.NoError(json.Unmarshal([]byte(`{"data":[{"name":"Saoirse","age":25},{"age":30}]}`), &eps))
+	err = Struct(&eps)
+	req.Equal(`field 'Data[1].Name' in 'validate.Envelope[[]github.com/mailstepcz/validate.Person]' is required
+field 'Meta' in 'validate.Envelope[[]github.com/mailstepcz/validate.Person]' is required`, err.Error())
+
+	var ee Envelope[Envelope[int]]
+	req.NoError(json.Unmarshal([]byte(`{"data":{"meta":"inner"},"meta":"outer"}`), &ee))
+	err = Struct(&ee)
+	req.Equal("field 'Data.Data' in 'validate.Envelope[github.com/mailstepcz/validate.Envelope[int]]' is required", err.Error())
+
+	var ei Envelope[int]
+	req.NoError(json.Unmarshal([]byte(`{"data":1,"meta":"v1"}`), &ei))
+	req.NoError(Struct(&ei))
+
+	err = New(WithTypeNames(func(reflect.Type) string { return "envelope" })).Struct(&ep)
+	req.Equal("field 'Data.Age' in 'envelope' is required", err.Error())
+}
+
+// Lazy decodes its value on the first access.
+type Lazy[T any] struct {
+	raw   json.RawMessage
+	once  sync.Once
+	value *T
+	inits atomic.Int32
+}
+
+func (l *Lazy[T]) UnmarshalJSON(b []byte) error {
+	l.raw = append(json.RawMessage(nil), b...)
+	return nil
+}
+
+func (l *Lazy[T]) Present() bool { return l.raw != nil }
+
+func (l *Lazy[T]) Inner() interface{} {
+	l.once.Do(func() {
+		l.inits.Add(1)
+		l.value = new(T)
+		_ = json.Unmarshal(l.raw, l.value)
+	})
+	return l.value
+}
+
+type Delivery struct {
+	ID        Required[string] `json:"id"`
+	Recipient Lazy[Person]     `json:"recipient"`
+}
+
+func TestLazyContainer(t *testing.T) {
+	req := require.New(t)
+
+	var d Delivery
+	req.NoError(json.Unmarshal([]byte(`{"id":"d1","recipient":{"name":"Saoirse"}}`), &d))
+	req.Nil(d.Recipient.value)
+
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, 8)
+	)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = Struct(&d)
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		req.Equal("field 'Recipient.Age' in 'validate.Delivery' is required", err.Error())
+	}
+	req.Equal(int32(1), d.Recipient.inits.Load())
+	req.Equal("Saoirse", d.Recipient.value.Name.Get())
+
+	var d2 Delivery
+	req.NoError(json.Unmarshal([]byte(`{"id":"d2"}`), &d2))
+	req.Equal("field 'Recipient' in 'validate.Delivery' is required", Struct(&d2).Error())
+	req.Equal(int32(0), d2.Recipient.inits.Load())
+}
+
+func TestStructReport(t *testing.T) {
+	req := require.New(t)
+
+	var o Order
+	req.NoError(json.Unmarshal([]byte(`{"id":"o1","address":{"street":"Main"},"billing":{"zip":"1"},"items":[{"sku":"a"},{}]}`), &o))
+	satisfied, err := StructReport(&o)
+	req.Equal([]string{"ID", "Address.Street", "Billing", "Billing.Zip", "Items[0].SKU"}, satisfied)
+	req.Equal(`field 'Address.Zip' in 'validate.Order' is required
+field 'Billing.Street' in 'validate.Order' is required
+field 'Items[1].SKU' in 'validate.Order' is required`, err.Error())
+
+	p := Person{Name: NewRequired("Saoirse"), Age: NewRequired(25)}
+	satisfied, err = StructReport(&p)
+	req.NoError(err)
+	req.Equal([]string{"Name", "Age"}, satisfied)
+
+	satisfied, err = StructReport(p)
+	req.Nil(satisfied)
+	req.ErrorIs(err, ErrBadType)
+}
+
+type Payment interface {
+	Method() string
+}
+
+type Card struct {
+	Number Required[string] `json:"number"`
+}
+
+func (c *Card) Method() string { return "card" }
+
+type Cash struct {
+	Currency Required[string] `json:"currency"`
+}
+
+func (c Cash) Method() string { return "cash" }
+
+type Checkout struct {
+	Payment
+	Total   Required[int] `json:"total"`
+	Details interface{}   `json:"details"`
+}
+
+func TestInterfaceFields(t *testing.T) {
+	req := require.New(t)
+
+	c := Checkout{Total: NewRequired(10)}
+	req.NotPanics(func() { req.NoError(Struct(&c)) })
+
+	c = Checkout{}
+	err := Struct(&c)
+	req.Equal("field 'Total' in 'validate.Checkout' is required", err.Error())
+
+	c = Checkout{Payment: &Card{}, Total: NewRequired(10), Details: map[string]interface{}{"note": "gift"}}
+	err = Struct(&c)
+	req.Equal("field 'Payment.Number' in 'validate.Checkout' is required", err.Error())
+	req.Equal("/Payment/number", err.(*ValidationError).Fields[0].Pointer)
+
+	c = Checkout{Payment: Cash{}, Total: NewRequired(10), Details: []interface{}{Cash{Currency: NewRequired("EUR")}, &Card{}, nil}}
+	err = Struct(&c)
+	req.Equal("field 'Payment.Currency' in 'validate.Checkout' is required\nfield 'Details[1].Number' in 'validate.Checkout' is required", err.Error())
+
+	var nilCard *Card
+	c = Checkout{Payment: nilCard, Total: NewRequired(10)}
+	req.NoError(Struct(&c))
+}
+
+type SearchForm struct {
+	Query  Required[string] `form:"q"`
+	Page   Required[int]    `form:"page,omitempty"`
+	Sort   Required[string]
+	Filter Address `form:"filter"`
+}
+
+func TestWithTagName(t *testing.T) {
+	req := require.New(t)
+
+	var f SearchForm
+	err := New(WithTagName("form")).Struct(&f)
+	req.Equal(`field 'q' in 'validate.SearchForm' is required
+field 'page' in 'validate.SearchForm' is required
+field 'Sort' in 'validate.SearchForm' is required
+field 'filter.Street' in 'validate.SearchForm' is required
+field 'filter.Zip' in 'validate.SearchForm' is required`, err.Error())
+
+	err = New(WithTagName("form"), WithSkipFields("q", "filter.Zip")).Struct(&f)
+	req.Equal(`field 'page' in 'validate.SearchForm' is required
+field 'Sort' in 'validate.SearchForm' is required
+field 'filter.Street' in 'validate.SearchForm' is required`, err.Error())
+
+	err = Struct(&f)
+	req.Equal("Query", err.(*ValidationError).Fields[0].Field)
+}
+
+type Identity struct {
+	AccountID  Required[string]
+	URL        Required[string]
+	HTTPServer Required[string]
+	ProfileURL Required[string]
+	FirstName  Required[string]
+	Zip        Required[string] `json:"postal_code"`
+	Address    Address
+}
+
+func TestWithLowerCamelNames(t *testing.T) {
+	req := require.New(t)
+
+	var id Identity
+	err := New(WithLowerCamelNames()).Struct(&id)
+	req.Equal(`field 'accountId' in 'validate.Identity' is required
+field 'url' in 'validate.Identity' is required
+field 'httpServer' in 'validate.Identity' is required
+field 'profileUrl' in 'validate.Identity' is required
+field 'firstName' in 'validate.Identity' is required
+field 'postal_code' in 'validate.Identity' is required
+field 'address.street' in 'validate.Identity' is required
+field 'address.zip' in 'validate.Identity' is required`, err.Error())
+
+	for in, out := range map[string]string{"ID": "id", "UserID": "userId", "A": "a", "Name": "name", "already": "already",
+		"IDs": "ids", "URLs": "urls", "UserIDs": "userIds", "IDsByName": "idsByName", "HTTPServer": "httpServer", "APIStatus": "apiStatus"} {
+		req.Equal(out, lowerCamel(in), in)
+	}
+}
+
+type Chain struct {
+	Name Required[string] `json:"name"`
+	Next *Chain           `json:"next"`
+	Any  interface{}      `json:"-"`
+}
+
+type Loop struct {
+	Self *Loop            `json:"self"`
+	A    Required[string] `json:"a"`
+}
+
+func TestStructCycles(t *testing.T) {
+	req := require.New(t)
+
+	c := &Chain{}
+	c.Next = c
+	req.EqualError(Struct(c), "field 'Name' in 'validate.Chain' is required")
+
+	a, b := &Chain{Name: NewRequired("a")}, &Chain{}
+	a.Next, b.Next = b, a
+	req.EqualError(Struct(a), "field 'Next.Name' in 'validate.Chain' is required")
+
+	// the root is entered by every traversal
+	l := &Loop{}
+	l.Self = l
+	req.EqualError(Struct(l), "field 'A' in 'validate.Loop' is required")
+	field, ok := FirstMissing(l)
+	req.True(ok)
+	req.Equal("A", field)
+
+	// cycles through interfaces, slices and maps
+	m := map[string]interface{}{}
+	m["self"] = m
+	s := []interface{}{nil}
+	s[0] = s
+	c = &Chain{Name: NewRequired("c")}
+	c.Any = []interface{}{m, s, c}
+	req.NoError(Struct(c))
+
+	// a structure shared by several paths is checked on each of them
+	shared := &Chain{}
+	c = &Chain{Name: NewRequired("c"), Any: []*Chain{shared, shared}}
+	req.EqualError(Struct(c), "field 'Any[0].Name' in 'validate.Chain' is required\n"+
+		"field 'Any[1].Name' in 'validate.Chain' is required")
+}
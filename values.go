@@ -0,0 +1,146 @@
+package validate
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// WithLastValue makes [Validator.ParseValues] assign the last of repeated values to fields which are not slices.
+// By default the first value is assigned.
+func WithLastValue() Option {
+	return func(v *Validator) {
+		v.lastValue = true
+	}
+}
+
+// ParseValues decodes URL query or form values into the fields of type [Required] of the structure obj points to
+// and validates it. The values are looked up by the `form` tags of the fields, falling back to their JSON names.
+// Repeated values of a key are collected into fields of slice underlying types; other fields get the first value.
+// Values which cannot be converted to the types of their fields are reported as [ErrInvalid] along with
+// the validation errors of the other fields.
+func ParseValues(values url.Values, obj interface{}) error {
+	return defaultValidator.ParseValues(values, obj)
+}
+
+// ParseValues is like [ParseValues] but validates the object with the validator.
+func (vd *Validator) ParseValues(values url.Values, obj interface{}) error {
+	v, err := structPointer(obj)
+	if err != nil {
+		return err
+	}
+	failed := make(map[string]error)
+	for _, f := range reflect.VisibleFields(v.Type()) {
+		if !f.IsExported() || !isRequiredField(f.Type) {
+			continue
+		}
+		vals := values[formName(f)]
+		if len(vals) == 0 {
+			continue
+		}
+		fv, err := fieldByIndexAlloc(v, f.Index)
+		if err != nil {
+			return err
+		}
+		x, _ := requiredAt(fv, true)
+		if err := vd.assignValues(x, vals); err != nil {
+			failed[vd.fieldName(f)] = invalid(err)
+		}
+	}
+	return vd.structAfter(obj, failed)
+}
+
+// structAfter validates the object and reports the errors of the fields which have failed to be assigned,
+// keyed by the names of the fields in errors, in place of their validation errors.
+func (vd *Validator) structAfter(obj interface{}, failed map[string]error) error {
+	if len(failed) == 0 {
+		return vd.Struct(obj)
+	}
+	v, err := structPointer(obj)
+	if err != nil {
+		return err
+	}
+	w := walker{vd: vd, typ: vd.nameType(v.Type()), failed: failed}
+	w.structure(v, "", "")
+	return w.err()
+}
+
+// assignValues sets the value of x from the string representations and marks it as valid.
+func (vd *Validator) assignValues(x RequiredIface, vals []string) error {
+	if err := writable(x); err != nil {
+		return err
+	}
+	t := x.RequiredType()
+	nv := reflect.New(t).Elem()
+	if t.Kind() == reflect.Slice && !reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		nv.Set(reflect.MakeSlice(t, len(vals), len(vals)))
+		for i, s := range vals {
+			if err := setString(nv.Index(i), s); err != nil {
+				return err
+			}
+		}
+	} else {
+		s := vals[0]
+		if vd.lastValue {
+			s = vals[len(vals)-1]
+		}
+		if err := setString(nv, s); err != nil {
+			return err
+		}
+	}
+	x.SettableValue().Set(nv)
+	x.SetValid(true)
+	return nil
+}
+
+// setString sets the settable value v from its string representation.
+// Types implementing [encoding.TextUnmarshaler] parse the representation themselves.
+func setString(v reflect.Value, s string) error {
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	default:
+		return fmt.Errorf("%w: cannot convert a string to %s", ErrBadType, v.Type())
+	}
+	return nil
+}
+
+// formName returns the key under which the value of the field is looked up in URL values.
+func formName(f reflect.StructField) string {
+	if name, _, _ := strings.Cut(f.Tag.Get("form"), ","); name != "" && name != "-" {
+		return name
+	}
+	return jsonName(f)
+}
@@ -0,0 +1,123 @@
+package validate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Filter struct {
+	IDs    Required[[]int]  `form:"id"`
+	Status Required[string] `json:"status"`
+	Limit  Required[int]    `json:"limit"`
+}
+
+func TestParseValues(t *testing.T) {
+	req := require.New(t)
+
+	values, err := url.ParseQuery("id=1&id=2&id=3&status=open&status=closed&limit=10")
+	req.NoError(err)
+	var f Filter
+	req.NoError(ParseValues(values, &f))
+	req.Equal([]int{1, 2, 3}, f.IDs.Value())
+	req.Equal("open", f.Status.Value())
+	req.Equal(10, f.Limit.Value())
+
+	f = Filter{}
+	req.NoError(New(WithLastValue()).ParseValues(values, &f))
+	req.Equal([]int{1, 2, 3}, f.IDs.Value())
+	req.Equal("closed", f.Status.Value())
+}
+
+func TestParseValuesErrors(t *testing.T) {
+	req := require.New(t)
+
+	values, err := url.ParseQuery("id=1&id=two&limit=ten")
+	req.NoError(err)
+	var f Filter
+	err = ParseValues(values, &f)
+	req.ErrorIs(err, ErrInvalid)
+	verr := err.(*ValidationError)
+	req.Len(verr.Fields, 3)
+	req.Equal("IDs", verr.Fields[0].Field)
+	req.Equal("/IDs", verr.Fields[0].Pointer)
+	req.ErrorIs(verr.Fields[0].Err, ErrInvalid)
+	req.Equal("field 'Status' in 'validate.Filter' is required", verr.Fields[1].Error())
+	req.Equal("Limit", verr.Fields[2].Field)
+	req.Equal("/limit", verr.Fields[2].Pointer)
+	req.ErrorIs(verr.Fields[2].Err, ErrInvalid)
+	req.False(f.IDs.HasValue())
+
+	f = Filter{}
+	err = New(WithTagName("form")).ParseValues(values, &f)
+	verr = err.(*ValidationError)
+	req.Len(verr.Fields, 3)
+	req.Equal("id", verr.Fields[0].Field)
+	req.Equal("Status", verr.Fields[1].Field)
+	req.Equal("Limit", verr.Fields[2].Field)
+	req.ErrorIs(verr.Fields[2].Err, ErrInvalid)
+}
+
+type Page struct {
+	Offset Required[int] `json:"offset"`
+	Limit  Required[int] `json:"limit"`
+}
+
+func TestParseValuesStructValidators(t *testing.T) {
+	req := require.New(t)
+
+	RegisterStructValidator(func(p *Page) error {
+		if p.Limit.Value() == 0 {
+			return ErrInvalid
+		}
+		return nil
+	})
+	values, err := url.ParseQuery("offset=x&limit=0")
+	req.NoError(err)
+	var p Page
+	err = ParseValues(values, &p)
+	verr := err.(*ValidationError)
+	req.Len(verr.Fields, 2)
+	req.Equal("Offset", verr.Fields[0].Field)
+	req.ErrorIs(verr.Fields[1].Err, ErrInvalid)
+	req.Equal("", verr.Fields[1].Field)
+}
+
+func TestBindForm(t *testing.T) {
+	req := require.New(t)
+
+	r := httptest.NewRequest(http.MethodPost, "/filters", strings.NewReader("id=4&id=5&status=open&limit=1"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	var f Filter
+	req.NoError(Bind(r, &f))
+	req.Equal([]int{4, 5}, f.IDs.Value())
+	req.Equal("open", f.Status.Value())
+}
+
+type Patch struct {
+	Count *Required[int]   `json:"count" default:"7"`
+	Note  Required[string] `json:"note"`
+}
+
+func TestParseValuesPointers(t *testing.T) {
+	req := require.New(t)
+
+	var p Patch
+	req.NoError(ParseValues(url.Values{"count": {"3"}, "note": {"x"}}, &p))
+	req.Equal(3, p.Count.Value())
+
+	r := httptest.NewRequest(http.MethodPost, "/patches", strings.NewReader("count=4&note=y"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	p = Patch{}
+	req.NoError(Bind(r, &p))
+	req.Equal(4, p.Count.Value())
+
+	p = Patch{}
+	err := ParseValues(url.Values{"count": {"x"}, "note": {"x"}}, &p)
+	req.ErrorIs(err, ErrInvalid)
+	req.Equal("Count", err.(*ValidationError).Fields[0].Field)
+}